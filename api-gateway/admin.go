@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	statsPb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// statsAdminClient is the gRPC client for the Stats service's admin API.
+var statsAdminClient statsPb.StatsAdminClient
+
+// adminContext returns a request context carrying the caller's Authorization
+// header, which the Stats service checks against its ADMIN_TOKEN.
+func adminContext(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+	return ctx, cancel
+}
+
+// requirePost writes an error and returns false unless r is a POST request.
+func requirePost(w http.ResponseWriter, r *http.Request, encoder *json.Encoder) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+	w.Header().Set("Allow", http.MethodPost)
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	encoder.Encode(map[string]string{"error": "method not allowed"})
+	return false
+}
+
+// AdminResetHandler deletes all recorded statistics.
+// Example request: POST /admin/stats/reset
+func AdminResetHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	if !requirePost(w, r, encoder) {
+		return
+	}
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	resp, err := statsAdminClient.Reset(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.Printf("gRPC Reset error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Println("Stats reset succeeded")
+	encoder.Encode(resp)
+}
+
+// AdminDeleteHandler deletes the statistics for one or more values of 'n'.
+// Example request: POST /admin/stats/delete?n=10&n=20
+func AdminDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	if !requirePost(w, r, encoder) {
+		return
+	}
+
+	req := &statsPb.DeleteStatsRequest{}
+	for _, nStr := range r.URL.Query()["n"] {
+		n, err := strconv.Atoi(nStr)
+		if err != nil {
+			log.Printf("Invalid input: %v", nStr)
+			encoder.Encode(map[string]string{"error": "invalid integer"})
+			return
+		}
+		req.N = append(req.N, int32(n))
+	}
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	resp, err := statsAdminClient.DeleteStats(ctx, req)
+	if err != nil {
+		log.Printf("gRPC DeleteStats error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Stats deletion for n=%v succeeded", req.N)
+	encoder.Encode(resp)
+}
+
+// AdminSnapshotsHandler lists snapshots (GET) or takes a new named one (POST).
+// Example request: POST /admin/stats/snapshots?name=before-deploy
+func AdminSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	var resp any
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		resp, err = statsAdminClient.ListSnapshots(ctx, &emptypb.Empty{})
+	case http.MethodPost:
+		resp, err = statsAdminClient.TakeSnapshot(ctx, &statsPb.TakeSnapshotRequest{Name: r.URL.Query().Get("name")})
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		encoder.Encode(map[string]string{"error": "method not allowed"})
+		return
+	}
+	if err != nil {
+		log.Printf("gRPC snapshot error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Println("Snapshot request succeeded")
+	encoder.Encode(resp)
+}
+
+// AdminDiffHandler reports what was recorded between two snapshots; omit to
+// compare against the current statistics.
+// Example request: GET /admin/stats/diff?from=before-deploy&to=after-deploy
+func AdminDiffHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	resp, err := statsAdminClient.DiffSnapshots(ctx, &statsPb.DiffSnapshotsRequest{
+		From: r.URL.Query().Get("from"),
+		To:   r.URL.Query().Get("to"),
+	})
+	if err != nil {
+		log.Printf("gRPC DiffSnapshots error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Println("Snapshot diff succeeded")
+	encoder.Encode(resp)
+}
+
+// exportFormats maps the format query parameter to the wire format, content type and file extension.
+var exportFormats = map[string]struct {
+	format      statsPb.StatsFormat
+	contentType string
+	ext         string
+}{
+	"ndjson": {statsPb.StatsFormat_STATS_FORMAT_NDJSON, "application/x-ndjson", "ndjson"},
+	"csv":    {statsPb.StatsFormat_STATS_FORMAT_CSV, "text/csv", "csv"},
+	"json":   {statsPb.StatsFormat_STATS_FORMAT_JSON, "application/json", "json"},
+}
+
+// AdminExportHandler downloads every stored aggregate as ndjson (default), csv or json.
+// Example request: GET /admin/stats/export?format=csv
+func AdminExportHandler(w http.ResponseWriter, r *http.Request) {
+	encoder := json.NewEncoder(w)
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = "ndjson"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		encoder.Encode(map[string]string{"error": "format must be ndjson, csv or json"})
+		return
+	}
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	stream, err := statsAdminClient.ExportStats(ctx, &statsPb.ExportStatsRequest{Format: format.format})
+	var chunk *statsPb.StatsChunk
+	if err == nil {
+		// Errors such as bad credentials only arrive with the first message
+		chunk, err = stream.Recv()
+	}
+	if err != nil && err != io.EOF {
+		log.Printf("gRPC ExportStats error: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", format.contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=stats."+format.ext)
+	for err == nil {
+		w.Write(chunk.GetData())
+		chunk, err = stream.Recv()
+	}
+	if err != io.EOF {
+		// Headers are already sent, so a truncated body is all the client sees
+		log.Printf("gRPC ExportStats error mid-stream: %v", err)
+		return
+	}
+	log.Println("Stats export succeeded")
+}
+
+// AdminImportHandler uploads an export in the request body. mode=replace deletes
+// the existing stats first; the default merges into them.
+// Example request: POST /admin/stats/import?format=csv&mode=replace
+func AdminImportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	if !requirePost(w, r, encoder) {
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("format")
+	if name == "" {
+		name = "ndjson"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		encoder.Encode(map[string]string{"error": "format must be ndjson, csv or json"})
+		return
+	}
+	mode := statsPb.ImportMode_IMPORT_MODE_MERGE
+	switch query.Get("mode") {
+	case "", "merge":
+	case "replace":
+		mode = statsPb.ImportMode_IMPORT_MODE_REPLACE
+	default:
+		encoder.Encode(map[string]string{"error": "mode must be merge or replace"})
+		return
+	}
+
+	ctx, cancel := adminContext(r)
+	defer cancel()
+
+	resp, err := uploadStats(ctx, r.Body, format.format, mode)
+	if err != nil {
+		log.Printf("gRPC ImportStats error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Stats import succeeded: %d records", resp.GetImported())
+	encoder.Encode(resp)
+}
+
+// uploadStats streams body to ImportStats in chunks.
+func uploadStats(ctx context.Context, body io.Reader, format statsPb.StatsFormat, mode statsPb.ImportMode) (*statsPb.ImportStatsResponse, error) {
+	stream, err := statsAdminClient.ImportStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &statsPb.ImportStatsRequest{Format: format, Mode: mode}
+	sent := false
+	buf := make([]byte, 32<<10)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			req.Data = buf[:n]
+			sent = true
+			if err := stream.Send(req); err != nil {
+				// The server ended the stream; CloseAndRecv reports why
+				break
+			}
+			req = &statsPb.ImportStatsRequest{}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	if !sent {
+		// An empty body still carries format and mode, so a replace clears the stats
+		stream.Send(req)
+	}
+	return stream.CloseAndRecv()
+}
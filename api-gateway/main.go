@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	pb "fibonacci-grpc/proto/fibonacci"
@@ -14,6 +17,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 // client is the gRPC client for the Fibonacci service.
@@ -23,10 +28,16 @@ var client pb.FibonacciClient
 var statsClient statsPb.StatsClient
 
 // FibHandler handles HTTP requests to calculate the Fibonacci number for a given 'n'.
+// The caller's X-Request-ID, or a generated one, is echoed back and recorded with the stats.
 // Example request: GET /fib?n=10
 func FibHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
+	requestID := r.Header.Get("X-Request-ID")
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	w.Header().Set("X-Request-ID", requestID)
 
 	nStr := r.URL.Query().Get("n")
 	n, err := strconv.Atoi(nStr)
@@ -43,6 +54,11 @@ func FibHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	// Pass the caller's identity on so stats can be broken down per client
+	if clientID := r.Header.Get("X-Client-ID"); clientID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-id", clientID)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", requestID)
 
 	resp, fibErr := client.GetFib(ctx, &pb.FibonacciRequest{N: int32(n)})
 	if fibErr != nil {
@@ -55,16 +71,86 @@ func FibHandler(w http.ResponseWriter, r *http.Request) {
 	encoder.Encode(resp)
 }
 
+// newRequestID returns a random ID for a request that arrived without one.
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// statsDimensions are the query parameters StatsHandler passes on as filters.
+var statsDimensions = []string{"n", "cache", "algorithm", "status", "instance", "client"}
+
 // StatsHandler handles HTTP requests to retrieve service statistics.
-// Example request: GET /stats
+// The optional window parameter ("1m", "5m", "1h", "24h") limits the stats to recent requests.
+// group_by takes a comma-separated list of dimensions, and any dimension given as a
+// parameter filters on that value. min_n, max_n, sort_by, descending, limit, page_size
+// and page_token select, order and page the rows. instances=true adds a per-instance breakdown.
+// Example request: GET /stats?window=5m&group_by=n,cache&client=web
+// Example request: GET /stats?sort_by=count&descending=true&limit=10
+// Example request: GET /stats?window=5m&instances=true
 func StatsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
+	query := r.URL.Query()
+
+	req := &statsPb.StatsRequest{Window: query.Get("window")}
+	if groupBy := query.Get("group_by"); groupBy != "" {
+		req.GroupBy = strings.Split(groupBy, ",")
+	}
+	for _, dim := range statsDimensions {
+		if query.Has(dim) {
+			if req.Filters == nil {
+				req.Filters = make(map[string]string)
+			}
+			req.Filters[dim] = query.Get(dim)
+		}
+	}
+	req.SortBy = query.Get("sort_by")
+	req.PageToken = query.Get("page_token")
+	for _, name := range []string{"descending", "instances"} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			encoder.Encode(map[string]string{"error": "invalid " + name + ": " + err.Error()})
+			return
+		}
+		switch name {
+		case "descending":
+			req.Descending = b
+		case "instances":
+			req.IncludeInstances = b
+		}
+	}
+	for _, name := range []string{"min_n", "max_n", "limit", "page_size"} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			encoder.Encode(map[string]string{"error": "invalid " + name + ": " + err.Error()})
+			return
+		}
+		switch name {
+		case "min_n":
+			req.MinN = proto.Int32(int32(i))
+		case "max_n":
+			req.MaxN = proto.Int32(int32(i))
+		case "limit":
+			req.Limit = int32(i)
+		case "page_size":
+			req.PageSize = int32(i)
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, statsErr := statsClient.GetStats(ctx, nil)
+	resp, statsErr := statsClient.GetStats(ctx, req)
 	if statsErr != nil {
 		log.Printf("gRPC Stats error: %v", statsErr)
 		encoder.Encode(map[string]string{"error": statsErr.Error()})
@@ -75,6 +161,153 @@ func StatsHandler(w http.ResponseWriter, r *http.Request) {
 	encoder.Encode(resp)
 }
 
+// TimeSeriesHandler handles HTTP requests for historical stats bucketed over time.
+// from and to accept RFC 3339 timestamps or Unix milliseconds, step a duration such as "1m",
+// and n may be repeated to get one series per number.
+// Example request: GET /stats/timeseries?from=2025-01-01T00:00:00Z&step=5m&n=10&n=20
+func TimeSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	query := r.URL.Query()
+
+	req := &statsPb.QueryStatsRequest{}
+	var err error
+	if req.FromMs, err = parseTimeMs(query.Get("from")); err != nil {
+		encoder.Encode(map[string]string{"error": "invalid from: " + err.Error()})
+		return
+	}
+	if req.ToMs, err = parseTimeMs(query.Get("to")); err != nil {
+		encoder.Encode(map[string]string{"error": "invalid to: " + err.Error()})
+		return
+	}
+	if stepStr := query.Get("step"); stepStr != "" {
+		step, stepErr := time.ParseDuration(stepStr)
+		if stepErr != nil {
+			encoder.Encode(map[string]string{"error": "invalid step: " + stepErr.Error()})
+			return
+		}
+		req.StepMs = step.Milliseconds()
+	}
+	for _, nStr := range query["n"] {
+		n, nErr := strconv.Atoi(nStr)
+		if nErr != nil {
+			log.Printf("Invalid input: %v", nStr)
+			encoder.Encode(map[string]string{"error": "invalid integer"})
+			return
+		}
+		req.N = append(req.N, int32(n))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, statsErr := statsClient.QueryStats(ctx, req)
+	if statsErr != nil {
+		log.Printf("gRPC QueryStats error: %v", statsErr)
+		encoder.Encode(map[string]string{"error": statsErr.Error()})
+		return
+	}
+
+	log.Println("Time series retrieval succeeded")
+	encoder.Encode(resp)
+}
+
+// AlertsHandler handles HTTP requests for the alerts raised by the Stats service's alerting rules.
+// Alerts still waiting out their rule's "for" duration are included with pending=true.
+// Example request: GET /stats/alerts?pending=true
+func AlertsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	req := &statsPb.GetAlertsRequest{}
+	if v := r.URL.Query().Get("pending"); v != "" {
+		pending, err := strconv.ParseBool(v)
+		if err != nil {
+			encoder.Encode(map[string]string{"error": "invalid pending: " + v})
+			return
+		}
+		req.IncludePending = pending
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := statsClient.GetAlerts(ctx, req)
+	if err != nil {
+		log.Printf("gRPC GetAlerts error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Alerts retrieval succeeded: %d alerts", len(resp.GetAlerts()))
+	encoder.Encode(resp)
+}
+
+// SlowLogHandler handles HTTP requests for the Stats service's log of slow computations,
+// newest first. threshold (a duration such as "50ms") raises the recording threshold for
+// this query, limit caps the entries returned and n may be repeated.
+// Example request: GET /stats/slowlog?threshold=50ms&limit=20&n=40
+func SlowLogHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	query := r.URL.Query()
+
+	req := &statsPb.GetSlowLogRequest{}
+	if v := query.Get("threshold"); v != "" {
+		threshold, err := time.ParseDuration(v)
+		if err != nil {
+			encoder.Encode(map[string]string{"error": "invalid threshold: " + err.Error()})
+			return
+		}
+		req.MinDurationUs = float64(threshold) / float64(time.Microsecond)
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			encoder.Encode(map[string]string{"error": "invalid limit: " + err.Error()})
+			return
+		}
+		req.Limit = int32(limit)
+	}
+	for _, nStr := range query["n"] {
+		n, err := strconv.Atoi(nStr)
+		if err != nil {
+			log.Printf("Invalid input: %v", nStr)
+			encoder.Encode(map[string]string{"error": "invalid integer"})
+			return
+		}
+		req.N = append(req.N, int32(n))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := statsClient.GetSlowLog(ctx, req)
+	if err != nil {
+		log.Printf("gRPC GetSlowLog error: %v", err)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Slow log retrieval succeeded: %d entries", len(resp.GetEntries()))
+	encoder.Encode(resp)
+}
+
+// parseTimeMs parses an RFC 3339 timestamp or Unix milliseconds. Empty input yields zero.
+func parseTimeMs(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return ms, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMilli(), nil
+}
+
 // main initializes the gRPC clients and starts the HTTP API gateway server.
 func main() {
 	// environment variable to determine the port that the app will run on
@@ -103,11 +336,21 @@ func main() {
 	}
 	defer statsConn.Close()
 	statsClient = statsPb.NewStatsClient(statsConn)
+	statsAdminClient = statsPb.NewStatsAdminClient(statsConn)
 	log.Printf("Connected to Stats gRPC service on :%s\n", statsUrl)
 
 	// Register HTTP handlers
 	http.HandleFunc("/fib", FibHandler)
 	http.HandleFunc("/stats", StatsHandler)
+	http.HandleFunc("/stats/timeseries", TimeSeriesHandler)
+	http.HandleFunc("/stats/alerts", AlertsHandler)
+	http.HandleFunc("/stats/slowlog", SlowLogHandler)
+	http.HandleFunc("/admin/stats/reset", AdminResetHandler)
+	http.HandleFunc("/admin/stats/delete", AdminDeleteHandler)
+	http.HandleFunc("/admin/stats/snapshots", AdminSnapshotsHandler)
+	http.HandleFunc("/admin/stats/diff", AdminDiffHandler)
+	http.HandleFunc("/admin/stats/export", AdminExportHandler)
+	http.HandleFunc("/admin/stats/import", AdminImportHandler)
 
 	log.Printf("API Gateway running on :%d\n", port)
 	if httpErr := http.ListenAndServe(":"+port, nil); httpErr != nil {
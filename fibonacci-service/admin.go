@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "fibonacci-grpc/proto/fibonacci"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// adminMethodPrefix matches every CacheAdmin RPC.
+const adminMethodPrefix = "/fibonacci.CacheAdmin/"
+
+// adminToken is the bearer token required by CacheAdmin. If unset, the admin API is disabled.
+var adminToken = os.Getenv("ADMIN_TOKEN")
+
+// cacheAdminServer implements the CacheAdmin gRPC service.
+type cacheAdminServer struct {
+	pb.UnimplementedCacheAdminServer
+}
+
+// AdminAuthInterceptor rejects CacheAdmin calls that don't carry the admin token.
+// Other services pass through untouched.
+func AdminAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, adminMethodPrefix) {
+		return handler(ctx, req)
+	}
+	if adminToken == "" {
+		return nil, status.Error(codes.PermissionDenied, "cache admin disabled (ADMIN_TOKEN not set)")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		token := strings.TrimPrefix(v, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return handler(ctx, req)
+		}
+	}
+	log.Printf("Rejected unauthenticated admin call to %s", info.FullMethod)
+	return nil, status.Error(codes.Unauthenticated, "invalid admin credentials")
+}
+
+// checkCache returns an Unavailable error while the breaker is open.
+func checkCache() error {
+	if !breaker.allow() {
+		return status.Error(codes.Unavailable, errCacheUnavailable.Error())
+	}
+	return nil
+}
+
+// cacheError converts a Redis error into a gRPC status.
+// Admin handlers feed every Redis result to the breaker as they get it, so a
+// successful call closes a half-open breaker just like a cached lookup does.
+func cacheError(err error) error {
+	return status.Errorf(codes.Unavailable, "redis: %v", err)
+}
+
+// scanCacheKeys returns every key in the cache namespace.
+func scanCacheKeys(ctx context.Context) ([]string, error) {
+	var keys []string
+	iter := rdb.Scan(ctx, 0, cacheKeyPrefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	breaker.observe(iter.Err())
+	return keys, iter.Err()
+}
+
+// cachedNs returns the sorted 'n' values that have a cache entry.
+func cachedNs(keys []string) []int {
+	ns := make([]int, 0, len(keys))
+	for _, k := range keys {
+		if n, err := strconv.Atoi(strings.TrimPrefix(k, cacheKeyPrefix)); err == nil {
+			ns = append(ns, n)
+		}
+	}
+	sort.Ints(ns)
+	return ns
+}
+
+// ListCachedRanges returns cached 'n' values collapsed into contiguous ranges.
+func (*cacheAdminServer) ListCachedRanges(ctx context.Context, _ *emptypb.Empty) (*pb.ListCachedRangesResponse, error) {
+	if err := checkCache(); err != nil {
+		return nil, err
+	}
+	keys, err := scanCacheKeys(ctx)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	var ranges []*pb.Range
+	for _, n := range cachedNs(keys) {
+		if last := len(ranges) - 1; last >= 0 && ranges[last].To == int32(n-1) {
+			ranges[last].To = int32(n)
+			continue
+		}
+		ranges = append(ranges, &pb.Range{From: int32(n), To: int32(n)})
+	}
+	return &pb.ListCachedRangesResponse{Ranges: ranges, TotalKeys: int32(len(keys))}, nil
+}
+
+// GetEntry returns the cached value for n together with its metadata.
+func (*cacheAdminServer) GetEntry(ctx context.Context, r *pb.GetEntryRequest) (*pb.CacheEntry, error) {
+	if err := checkCache(); err != nil {
+		return nil, err
+	}
+	key := cacheKey(int(r.GetN()))
+	raw, err := rdb.Get(ctx, key).Bytes()
+	breaker.observe(err)
+	if err == redis.Nil {
+		return nil, status.Errorf(codes.NotFound, "%s not cached", key)
+	}
+	if err != nil {
+		return nil, cacheError(err)
+	}
+
+	res := &pb.CacheEntry{N: r.GetN(), Key: key}
+	if entry, decErr := decodeEntry(key, raw); decErr == nil {
+		if entry.Value.IsInt64() {
+			res.Value = entry.Value.Int64()
+		}
+		res.DecimalValue = entry.Value.String()
+		res.Encoding = encodingName(entry.Encoding)
+		res.StoredBytes = int32(entry.Size)
+		res.ComputedAtMs = entry.ComputedAt.UnixMilli()
+		res.Valid = true
+	}
+	ttl, err := rdb.PTTL(ctx, key).Result()
+	breaker.observe(err)
+	if err == nil {
+		res.TtlMs = ttl.Milliseconds()
+		if ttl < 0 {
+			res.TtlMs = -1
+		}
+	}
+	size, err := rdb.MemoryUsage(ctx, key).Result()
+	breaker.observe(err)
+	if err == nil {
+		res.SizeBytes = size
+	}
+	return res, nil
+}
+
+// Invalidate deletes the cached value for a single n or an inclusive range of n.
+// Ranges are clamped to the n that can be cached, [0, maxN].
+func (*cacheAdminServer) Invalidate(ctx context.Context, r *pb.InvalidateRequest) (*pb.InvalidateResponse, error) {
+	if err := checkCache(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	switch t := r.GetTarget().(type) {
+	case *pb.InvalidateRequest_N:
+		keys = []string{cacheKey(int(t.N))}
+	case *pb.InvalidateRequest_Range:
+		if t.Range.GetFrom() > t.Range.GetTo() {
+			return nil, status.Error(codes.InvalidArgument, "range from must be <= to")
+		}
+		// Only n in [0, maxN] is ever cached
+		for n := max(int(t.Range.GetFrom()), 0); n <= min(int(t.Range.GetTo()), maxN); n++ {
+			keys = append(keys, cacheKey(n))
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "n or range is required")
+	}
+
+	deleted, err := deleteKeys(ctx, keys)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+	log.Printf("Admin invalidated %d cache entries", deleted)
+	return &pb.InvalidateResponse{Deleted: int32(deleted)}, nil
+}
+
+// Flush deletes every key in the cache namespace.
+func (*cacheAdminServer) Flush(ctx context.Context, _ *emptypb.Empty) (*pb.InvalidateResponse, error) {
+	if err := checkCache(); err != nil {
+		return nil, err
+	}
+	keys, err := scanCacheKeys(ctx)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+	deleted, err := deleteKeys(ctx, keys)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+	log.Printf("Admin flushed cache namespace: %d entries deleted", deleted)
+	return &pb.InvalidateResponse{Deleted: int32(deleted)}, nil
+}
+
+// GetMemoryUsage sums MEMORY USAGE over the namespace and reports instance-wide usage.
+func (*cacheAdminServer) GetMemoryUsage(ctx context.Context, _ *emptypb.Empty) (*pb.MemoryUsageResponse, error) {
+	res := &pb.MemoryUsageResponse{IntegrityFailures: integrityFailures.Load()}
+	if err := checkCache(); err != nil {
+		return res, nil
+	}
+	res.CacheAvailable = true
+
+	keys, err := scanCacheKeys(ctx)
+	if err != nil {
+		return nil, cacheError(err)
+	}
+	pipe := rdb.Pipeline()
+	cmds := make([]*redis.IntCmd, len(keys))
+	for i, k := range keys {
+		cmds[i] = pipe.MemoryUsage(ctx, k)
+	}
+	_, err = pipe.Exec(ctx)
+	breaker.observe(err)
+	if err != nil && err != redis.Nil {
+		return nil, cacheError(err)
+	}
+	for _, c := range cmds {
+		res.Bytes += c.Val()
+	}
+	res.Keys = int32(len(keys))
+
+	info, err := rdb.InfoMap(ctx, "memory").Result()
+	breaker.observe(err)
+	if err == nil {
+		if v, ok := info["Memory"]["used_memory"]; ok {
+			res.RedisUsedMemory, _ = strconv.ParseInt(v, 10, 64)
+		}
+	}
+	return res, nil
+}
+
+// deleteKeys removes keys in batches and returns how many existed.
+func deleteKeys(ctx context.Context, keys []string) (int64, error) {
+	const batch = 500
+	var deleted int64
+	for start := 0; start < len(keys); start += batch {
+		end := min(start+batch, len(keys))
+		n, err := rdb.Del(ctx, keys[start:end]...).Result()
+		breaker.observe(err)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
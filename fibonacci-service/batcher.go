@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	statsPb "fibonacci-grpc/proto/stats"
+)
+
+// statsBatcher collects stats records and sends them to the Stats service in
+// batches, so ingestion costs one RPC per batch rather than one per request.
+// A batch is flushed when it reaches maxSize records or every interval.
+type statsBatcher struct {
+	records  chan *statsPb.RecordRequest
+	maxSize  int
+	interval time.Duration
+}
+
+// newStatsBatcher starts a batcher that buffers up to queueSize pending records.
+func newStatsBatcher(maxSize, queueSize int, interval time.Duration) *statsBatcher {
+	b := &statsBatcher{
+		records:  make(chan *statsPb.RecordRequest, queueSize),
+		maxSize:  maxSize,
+		interval: interval,
+	}
+	go b.run()
+	return b
+}
+
+// add queues a record without blocking. If the queue is full (the Stats
+// service is down or too slow) the record is dropped, keeping stats
+// fire-and-forget for the request path.
+func (b *statsBatcher) add(r *statsPb.RecordRequest) {
+	select {
+	case b.records <- r:
+	default:
+		log.Printf("Stats queue full, dropping record for n=%d", r.GetN())
+	}
+}
+
+// run accumulates records and flushes them by size or on the ticker.
+func (b *statsBatcher) run() {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	batch := make([]*statsPb.RecordRequest, 0, b.maxSize)
+	for {
+		select {
+		case r := <-b.records:
+			batch = append(batch, r)
+			if len(batch) < b.maxSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		b.flush(batch)
+		batch = make([]*statsPb.RecordRequest, 0, b.maxSize)
+	}
+}
+
+// flush sends one batch with retries.
+func (b *statsBatcher) flush(batch []*statsPb.RecordRequest) {
+	var res *statsPb.RecordBatchResponse
+	err := RetryGRPC(3, 100*time.Millisecond, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		var err error
+		res, err = statsClient.RecordBatch(ctx, &statsPb.RecordBatchRequest{Records: batch})
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to record stats batch of %d: %v", len(batch), err)
+		return
+	}
+	if res.GetFailed() > 0 {
+		log.Printf("Stats service rejected %d of %d records", res.GetFailed(), len(batch))
+	}
+	if res.GetDuplicates() > 0 {
+		log.Printf("Stats service had already recorded %d of %d records", res.GetDuplicates(), len(batch))
+	}
+}
+
+// newEventID returns a random ID for a stats record. Retried batches resend
+// the same IDs, so the Stats service counts each record once.
+func newEventID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
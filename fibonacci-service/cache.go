@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// cacheKeyPrefix namespaces every Fibonacci entry in Redis.
+	cacheKeyPrefix = "fib:"
+
+	// cacheHealthService is the health-check service name that reports Redis availability.
+	cacheHealthService = "fibonacci.cache"
+
+	breakerThreshold  = 3                // consecutive failures before the breaker opens
+	breakerCooldown   = 10 * time.Second // how long an open breaker rejects calls before allowing a probe
+	reconnectInterval = 5 * time.Second  // how often the background loop pings Redis
+)
+
+// errCacheUnavailable is returned instead of calling Redis while the breaker is open.
+var errCacheUnavailable = errors.New("cache unavailable")
+
+// healthServer reports overall and cache health over the standard gRPC health protocol.
+// The service itself stays SERVING while Redis is down; only the cache entry degrades.
+var healthServer = health.NewServer()
+
+// breaker guards every Redis call made by the service.
+var breaker = &cacheBreaker{}
+
+// cacheBreaker is a simple circuit breaker for Redis.
+// After breakerThreshold consecutive failures it opens and short-circuits calls
+// for breakerCooldown, after which a single probe is let through (half-open).
+type cacheBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	open      bool
+	openUntil time.Time
+}
+
+// allow reports whether a Redis call may be attempted right now.
+func (b *cacheBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	if time.Now().Before(b.openUntil) {
+		return false
+	}
+	// Half-open: let this call probe Redis, keep others out until it reports back.
+	b.openUntil = time.Now().Add(breakerCooldown)
+	return true
+}
+
+// success closes the breaker and resets the failure count.
+func (b *cacheBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	if b.open {
+		b.open = false
+		log.Printf("Redis reachable again, cache enabled")
+		healthServer.SetServingStatus(cacheHealthService, healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+// failure records a failed call and opens the breaker once the threshold is reached.
+func (b *cacheBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures < breakerThreshold {
+		return
+	}
+	b.openUntil = time.Now().Add(breakerCooldown)
+	if !b.open {
+		b.open = true
+		log.Printf("Redis failing (%d consecutive errors), serving uncached", b.failures)
+		healthServer.SetServingStatus(cacheHealthService, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// trip opens the breaker immediately, e.g. when Redis is unreachable at startup.
+func (b *cacheBreaker) trip() {
+	b.mu.Lock()
+	b.failures = breakerThreshold - 1
+	b.mu.Unlock()
+	b.failure()
+}
+
+// observe feeds the outcome of a Redis call into the breaker.
+// redis.Nil is a cache miss, not a failure.
+func (b *cacheBreaker) observe(err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		b.success()
+	} else {
+		b.failure()
+	}
+}
+
+// InitRedis creates the Redis client and starts the background health loop.
+// If Redis is unreachable the service still starts and computes uncached
+// until the loop sees Redis come back.
+func InitRedis() {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "redis:6379"
+	}
+	rdb = redis.NewClient(&redis.Options{
+		Addr:         addr,
+		DialTimeout:  time.Second,
+		ReadTimeout:  500 * time.Millisecond,
+		WriteTimeout: 500 * time.Millisecond,
+		MaxRetries:   -1, // the breaker decides when to try again
+	})
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(cacheHealthService, healthpb.HealthCheckResponse_SERVING)
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.Printf("Redis not reachable at %s, starting in degraded mode: %v", addr, err)
+		breaker.trip()
+	} else {
+		log.Printf("Connected to Redis at %s", addr)
+	}
+
+	go monitorRedis()
+}
+
+// monitorRedis pings Redis periodically so the breaker closes as soon as
+// Redis recovers, without waiting for live traffic to probe it.
+func monitorRedis() {
+	ticker := time.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		breaker.observe(rdb.Ping(ctx).Err())
+	}
+}
+
+// cacheKey returns the Redis key holding Fib(n).
+func cacheKey(n int) string {
+	return fmt.Sprintf("%s%d", cacheKeyPrefix, n)
+}
+
+// cacheGet reads key from Redis unless the breaker is open.
+func cacheGet(key string) ([]byte, error) {
+	if !breaker.allow() {
+		return nil, errCacheUnavailable
+	}
+	val, err := rdb.Get(ctx, key).Bytes()
+	breaker.observe(err)
+	return val, err
+}
+
+// cacheSet writes key to Redis unless the breaker is open.
+func cacheSet(key string, value interface{}) error {
+	if !breaker.allow() {
+		return errCacheUnavailable
+	}
+	err := rdb.Set(ctx, key, value, 0).Err()
+	breaker.observe(err)
+	return err
+}
+
+// cacheDel removes key from Redis unless the breaker is open.
+func cacheDel(key string) error {
+	if !breaker.allow() {
+		return errCacheUnavailable
+	}
+	err := rdb.Del(ctx, key).Err()
+	breaker.observe(err)
+	return err
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Cache values are stored as a sealed binary record:
+//
+//	[version:1][encoding:1][computedAtMs:8][payload...][mac:32]
+//
+// The payload is the big-endian magnitude of F(n), zstd-compressed when it
+// exceeds compressThreshold.
+const (
+	entryVersion    byte = 2
+	entryHeaderSize      = 10
+
+	encodingRaw  byte = 0
+	encodingZstd byte = 1
+)
+
+var (
+	// errStaleEntry is returned for values written in an older format.
+	errStaleEntry = errors.New("cache entry in outdated format")
+
+	// errEntryTooLarge is returned when an encoded entry exceeds maxEntryBytes.
+	errEntryTooLarge = errors.New("cache entry too large")
+)
+
+// compressThreshold is the payload size in bytes above which values are
+// compressed (CACHE_COMPRESS_THRESHOLD, default 256; 0 disables compression).
+var compressThreshold = envInt("CACHE_COMPRESS_THRESHOLD", 256)
+
+// maxEntryBytes caps the stored size of a single entry; larger results are not
+// cached (CACHE_MAX_ENTRY_BYTES, default 1 MiB).
+var maxEntryBytes = envInt("CACHE_MAX_ENTRY_BYTES", 1<<20)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(maxDecodedBytes())))
+)
+
+// cacheEntry is a Fibonacci result plus the metadata stored alongside it in Redis.
+type cacheEntry struct {
+	Value      *big.Int
+	ComputedAt time.Time
+	Encoding   byte // encodingRaw or encodingZstd
+	Size       int  // stored size in bytes, including header and signature
+}
+
+// encodingName returns a human-readable name for an entry encoding.
+func encodingName(enc byte) string {
+	switch enc {
+	case encodingRaw:
+		return "raw"
+	case encodingZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", enc)
+	}
+}
+
+// encodeEntry serializes and signs e for storage under key.
+func encodeEntry(key string, e cacheEntry) ([]byte, error) {
+	payload := e.Value.Bytes()
+	enc := encodingRaw
+	if compressThreshold > 0 && len(payload) > compressThreshold {
+		if compressed := zstdEncoder.EncodeAll(payload, nil); len(compressed) < len(payload) {
+			payload, enc = compressed, encodingZstd
+		}
+	}
+
+	size := entryHeaderSize + len(payload) + macSize
+	if size > maxEntryBytes {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", errEntryTooLarge, size, maxEntryBytes)
+	}
+
+	buf := make([]byte, entryHeaderSize, size)
+	buf[0] = entryVersion
+	buf[1] = enc
+	binary.BigEndian.PutUint64(buf[2:], uint64(e.ComputedAt.UnixMilli()))
+	buf = append(buf, payload...)
+	return sealEntry(key, buf), nil
+}
+
+// decodeEntry validates and parses a value read from key.
+func decodeEntry(key string, raw []byte) (cacheEntry, error) {
+	if len(raw) == 0 || raw[0] != entryVersion {
+		return cacheEntry{}, errStaleEntry
+	}
+	body, err := openEntry(key, raw)
+	if err != nil {
+		return cacheEntry{}, err
+	}
+	if len(body) < entryHeaderSize {
+		return cacheEntry{}, errCorruptEntry
+	}
+
+	entry := cacheEntry{
+		Encoding:   body[1],
+		ComputedAt: time.UnixMilli(int64(binary.BigEndian.Uint64(body[2:]))),
+		Size:       len(raw),
+	}
+	payload := body[entryHeaderSize:]
+	switch entry.Encoding {
+	case encodingRaw:
+	case encodingZstd:
+		if payload, err = zstdDecoder.DecodeAll(payload, nil); err != nil {
+			return cacheEntry{}, fmt.Errorf("%w: %v", errCorruptEntry, err)
+		}
+	default:
+		return cacheEntry{}, errCorruptEntry
+	}
+	entry.Value = new(big.Int).SetBytes(payload)
+	return entry, nil
+}
+
+// maxDecodedBytes bounds decompression so a hostile entry can't exhaust memory.
+// Fibonacci magnitudes are effectively incompressible, so decoded values can't
+// legitimately be much larger than maxEntryBytes.
+func maxDecodedBytes() int {
+	return 4 * max(maxEntryBytes, 1<<16)
+}
+
+// envInt reads an integer setting from the environment, falling back to def.
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using %d", name, v, def)
+		return def
+	}
+	return i
+}
+
+// envPositiveInt is envInt for settings that must be positive.
+func envPositiveInt(name string, def int) int {
+	i := envInt(name, def)
+	if i <= 0 {
+		log.Printf("Invalid %s=%d, must be positive, using %d", name, i, def)
+		return def
+	}
+	return i
+}
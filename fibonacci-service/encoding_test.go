@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestEncodeEntryRoundTrip(t *testing.T) {
+	computedAt := time.UnixMilli(1700000000123)
+	tests := []struct {
+		name   string
+		value  *big.Int
+		macKey string
+		want   byte // encoding
+	}{
+		{name: "zero", value: big.NewInt(0), want: encodingRaw},
+		{name: "largest int64 result", value: computeFib(maxN), want: encodingRaw},
+		{name: "incompressible big value", value: computeFib(5000), want: encodingRaw},
+		{name: "compressible big value", value: new(big.Int).Lsh(big.NewInt(1), 8*4096), want: encodingZstd},
+		{name: "signed with HMAC key", value: computeFib(50), macKey: "secret", want: encodingRaw},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(k []byte) { macKey = k }(macKey)
+			macKey = []byte(tt.macKey)
+
+			raw, err := encodeEntry("fib:1", cacheEntry{Value: tt.value, ComputedAt: computedAt})
+			if err != nil {
+				t.Fatalf("encodeEntry: %v", err)
+			}
+			e, err := decodeEntry("fib:1", raw)
+			if err != nil {
+				t.Fatalf("decodeEntry: %v", err)
+			}
+			if e.Value.Cmp(tt.value) != 0 {
+				t.Errorf("value = %v, want %v", e.Value, tt.value)
+			}
+			if !e.ComputedAt.Equal(computedAt) {
+				t.Errorf("computed at %v, want %v", e.ComputedAt, computedAt)
+			}
+			if e.Encoding != tt.want {
+				t.Errorf("encoding = %s, want %s", encodingName(e.Encoding), encodingName(tt.want))
+			}
+			if e.Size != len(raw) {
+				t.Errorf("size = %d, want %d", e.Size, len(raw))
+			}
+		})
+	}
+}
+
+func TestDecodeEntryRejects(t *testing.T) {
+	valid, err := encodeEntry("fib:10", cacheEntry{Value: computeFib(10), ComputedAt: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// resealed returns a correctly signed entry with the given header and payload
+	resealed := func(enc byte, payload []byte) []byte {
+		body := append([]byte{entryVersion, enc, 0, 0, 0, 0, 0, 0, 0, 0}, payload...)
+		return sealEntry("fib:10", body)
+	}
+	tests := []struct {
+		name string
+		key  string
+		raw  []byte
+		want error
+	}{
+		{name: "empty", key: "fib:10", raw: nil, want: errStaleEntry},
+		{name: "older version", key: "fib:10", raw: append([]byte{entryVersion - 1}, valid[1:]...), want: errStaleEntry},
+		{name: "legacy decimal string", key: "fib:10", raw: []byte("55"), want: errStaleEntry},
+		{name: "flipped payload bit", key: "fib:10", raw: flipBit(valid, entryHeaderSize), want: errCorruptEntry},
+		{name: "flipped signature bit", key: "fib:10", raw: flipBit(valid, len(valid)-1), want: errCorruptEntry},
+		{name: "truncated", key: "fib:10", raw: valid[:len(valid)-1], want: errCorruptEntry},
+		{name: "copied under another key", key: "fib:11", raw: valid, want: errCorruptEntry},
+		{name: "short header", key: "fib:10", raw: sealEntry("fib:10", []byte{entryVersion, encodingRaw}), want: errCorruptEntry},
+		{name: "unknown encoding", key: "fib:10", raw: resealed(7, []byte{55}), want: errCorruptEntry},
+		{name: "invalid zstd payload", key: "fib:10", raw: resealed(encodingZstd, []byte("not zstd")), want: errCorruptEntry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeEntry(tt.key, tt.raw); !errors.Is(err, tt.want) {
+				t.Errorf("decodeEntry error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeEntryTooLarge(t *testing.T) {
+	defer func(max int) { maxEntryBytes = max }(maxEntryBytes)
+	maxEntryBytes = 100
+
+	if _, err := encodeEntry("fib:92", cacheEntry{Value: computeFib(92), ComputedAt: time.Now()}); err != nil {
+		t.Errorf("small entry: %v", err)
+	}
+	if _, err := encodeEntry("fib:1000", cacheEntry{Value: computeFib(1000), ComputedAt: time.Now()}); !errors.Is(err, errEntryTooLarge) {
+		t.Errorf("large entry error = %v, want %v", err, errEntryTooLarge)
+	}
+}
+
+// flipBit returns a copy of b with the lowest bit of b[i] flipped.
+func flipBit(b []byte, i int) []byte {
+	c := append([]byte(nil), b...)
+	c[i] ^= 1
+	return c
+}
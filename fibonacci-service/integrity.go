@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"log"
+	"os"
+	"sync/atomic"
+)
+
+// macSize is the length of the signature appended to every cache value.
+const macSize = sha256.Size
+
+// errCorruptEntry is returned when a cached value fails validation.
+var errCorruptEntry = errors.New("corrupt cache entry")
+
+// integrityFailures counts cached values that were rejected and repaired.
+var integrityFailures atomic.Int64
+
+// macKey signs cache entries. When CACHE_HMAC_KEY is unset entries are only
+// checksummed, which catches corruption but not deliberate tampering.
+var macKey = []byte(os.Getenv("CACHE_HMAC_KEY"))
+
+// newMAC returns the hash used to sign entries: HMAC-SHA256 if a key is set, plain SHA-256 otherwise.
+func newMAC() hash.Hash {
+	if len(macKey) == 0 {
+		return sha256.New()
+	}
+	return hmac.New(sha256.New, macKey)
+}
+
+// entrySum signs the payload together with its Redis key, so a valid value
+// copied under another key is still rejected.
+func entrySum(key string, payload []byte) []byte {
+	m := newMAC()
+	m.Write([]byte(key))
+	m.Write([]byte{0})
+	m.Write(payload)
+	return m.Sum(nil)
+}
+
+// sealEntry appends the signature for payload stored under key.
+func sealEntry(key string, payload []byte) []byte {
+	return append(payload, entrySum(key, payload)...)
+}
+
+// openEntry verifies the signature on a sealed value and returns its payload.
+func openEntry(key string, raw []byte) ([]byte, error) {
+	if len(raw) < macSize {
+		return nil, errCorruptEntry
+	}
+	payload, sum := raw[:len(raw)-macSize], raw[len(raw)-macSize:]
+	if !hmac.Equal(sum, entrySum(key, payload)) {
+		return nil, errCorruptEntry
+	}
+	return payload, nil
+}
+
+// repairEntry removes a value that failed decoding so it is recomputed.
+// Only genuine integrity failures are counted; entries in an older format are
+// simply replaced.
+func repairEntry(key string, cause error) {
+	if errors.Is(cause, errCorruptEntry) {
+		total := integrityFailures.Add(1)
+		log.Printf("Integrity check failed for %s, deleting (failures so far: %d)", key, total)
+	} else {
+		log.Printf("Replacing cache entry %s: %v", key, cause)
+	}
+	if err := cacheDel(key); err != nil && err != errCacheUnavailable {
+		log.Printf("Failed to delete cache entry %s: %v", key, err)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 	"net"
-	"strconv"
 	"os"
 	"time"
 
@@ -16,6 +16,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -27,24 +29,21 @@ type fibonacciServer struct {
 // statsClient is the gRPC client for sending statistics to the Stats service.
 var statsClient statsPb.StatsClient
 
+// batcher buffers stats records and sends them to the Stats service in batches.
+var batcher *statsBatcher
+
+// sampler picks which requests are reported to the Stats service.
+var sampler *statsSampler
+
+// instanceID identifies this replica in stats records (INSTANCE_ID, or the hostname).
+var instanceID string
+
 // the client for redis; used for caching
 var rdb *redis.Client
 
 // background context for redis
 var ctx = context.Background()
 
-// initialize the redis client
-func InitRedis() {
-	rdb = redis.NewClient(&redis.Options{
-		Addr: "redis:6379",
-	})
-	_, err := rdb.Ping(ctx).Result()
-	if err != nil {
-		log.Fatalf("Redis not reachable: %v", err)
-	}
-
-}
-
 func RetryGRPC(maxRetries int, baseDelay time.Duration, f func() error) error {
 	var err error
 	delay := baseDelay
@@ -72,41 +71,75 @@ func RetryGRPC(maxRetries int, baseDelay time.Duration, f func() error) error {
 	return err // return last error if all retries fail
 }
 
+// maxN is the largest 'n' whose Fibonacci number fits in an int64.
+const maxN = 92
+
+// Algorithms reported to the Stats service for how a result was produced.
+const (
+	algoBase      = "base"      // n < 2, returned directly
+	algoCache     = "cache"     // served from the Redis cache
+	algoIterative = "iterative" // computed iteratively
+)
+
+// clientIDHeader is the incoming metadata key identifying the calling client.
+const clientIDHeader = "x-client-id"
+
+// requestIDHeader is the incoming metadata key carrying the gateway's request ID.
+const requestIDHeader = "x-request-id"
+
 // GetFib calculates the Fibonacci number for a given 'n'.
-// It returns an error if 'n' is greater than 92 to prevent int64 overflow.
-func (*fibonacciServer) GetFib(_ context.Context, r *pb.FibonacciRequest) (*pb.FibonacciResponse, error) {
+// It returns an error if 'n' is negative, or greater than maxN to prevent int64 overflow.
+// Every request is reported to the Stats service, including rejected ones.
+func (*fibonacciServer) GetFib(ctx context.Context, r *pb.FibonacciRequest) (*pb.FibonacciResponse, error) {
 	n := int(r.GetN())
-	if n > 92 {
+	start := time.Now()
+	rec := &statsPb.RecordRequest{
+		N:           int32(n),
+		InstanceId:  instanceID,
+		ClientId:    incomingValue(ctx, clientIDHeader),
+		RequestId:   incomingValue(ctx, requestIDHeader),
+		TimestampMs: start.UnixMilli(),
+		EventId:     newEventID(),
+	}
+	// Fire-and-forget stats update, sent in the next batch unless sampled out
+	defer func() {
+		rec.Duration = time.Since(start).Nanoseconds()
+		if rec.SampleWeight = sampler.weight(rec); rec.SampleWeight > 0 {
+			batcher.add(rec)
+		}
+	}()
+
+	if n < 0 {
+		log.Printf("Received negative n: %d", n)
+		rec.StatusCode = int32(codes.InvalidArgument)
+		return nil, status.Error(codes.InvalidArgument, "n must be non-negative")
+	}
+	if n > maxN {
 		log.Printf("Received too large n: %d", n)
+		rec.StatusCode = int32(codes.InvalidArgument)
 		return nil, status.Error(codes.InvalidArgument, "n too large (max 92)")
 	}
 
-	start := time.Now()
-	res := int64(Fib(n))
-	duration := time.Since(start)
-
-	log.Printf("Computed Fib(%d) = %d in %v", n, res, duration)
-
-	// Fire-and-forget stats update
-	go func(n int, dur time.Duration) {
-		err := RetryGRPC(3, 100*time.Millisecond, func() error {
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			defer cancel()
-			_, err := statsClient.RecordNo(ctx, &statsPb.RecordRequest{
-				N:        int32(n),
-				Duration: dur.Nanoseconds(),
-			})
-			return err
-		})
-		if err != nil {
-			// optional: log the error
-			log.Printf("Failed to record stats for n=%d: %v", n, err)
-		}
-	}(n, duration)
+	fib, cacheStatus, algorithm := Fib(n)
+	res := fib.Int64()
+	rec.CacheStatus = cacheStatus
+	rec.Algorithm = algorithm
+	rec.StatusCode = int32(codes.OK)
+	rec.ResultSize = int32(len(fib.Bytes()))
 
+	log.Printf("Computed Fib(%d) = %d in %v", n, res, time.Since(start))
 	return &pb.FibonacciResponse{X: res}, nil
 }
 
+// incomingValue returns the first value of the caller's metadata key, if any.
+func incomingValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vs := md.Get(key); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
 // FibSlow calculates Fibonacci recursively without caching (for testing duration).
 func FibSlow(n int) int {
 	if n == 0 {
@@ -119,47 +152,79 @@ func FibSlow(n int) int {
 }
 
 // Fib calculates Fibonacci using a cache for performance.
-func Fib(n int) int {
-	if n == 0 {
-		return 0
+// It also reports how the cache was used and which algorithm produced the result.
+// n must not be negative.
+func Fib(n int) (*big.Int, statsPb.CacheStatus, string) {
+	if n < 2 {
+		return big.NewInt(int64(n)), statsPb.CacheStatus_CACHE_STATUS_BYPASS, algoBase
 	}
-	if n == 1 {
-		return 1
+
+	cached, cacheStatus := lookupFib(n)
+	if cacheStatus == statsPb.CacheStatus_CACHE_STATUS_HIT {
+		return cached, cacheStatus, algoCache
 	}
+	res := computeFib(n)
+	storeFib(n, res)
+	return res, cacheStatus, algoIterative
+}
 
-	cacheKey := fmt.Sprintf("fib:%d", n)
-	cached, err := rdb.Get(ctx, cacheKey).Result()
+// lookupFib returns the cached Fib(n) with CACHE_STATUS_HIT if a valid entry
+// exists. Otherwise it reports a miss, or a bypass when Redis is unavailable.
+func lookupFib(n int) (*big.Int, statsPb.CacheStatus) {
+	key := cacheKey(n)
+	cached, err := cacheGet(key)
 	if err == nil {
-		// Cache hit
-		log.Printf("Cache hit for Fib(%d) = %s", n, cached)
-		cachedI, convErr := strconv.ParseInt(cached, 10, 64)
-		if convErr != nil {
-			log.Printf("Failed to parse cached value: %v", convErr)
-		} else {
-			return int(cachedI)
+		// Cache hit, but only trust it if it validates
+		entry, decErr := decodeEntry(key, cached)
+		if decErr == nil {
+			log.Printf("Cache hit for Fib(%d) = %s (%s, %d bytes)", n, entry.Value, encodingName(entry.Encoding), entry.Size)
+			return entry.Value, statsPb.CacheStatus_CACHE_STATUS_HIT
 		}
+		repairEntry(key, decErr)
 	} else if err == redis.Nil {
 		log.Printf("Cache miss for Fib(%d)", n)
+	} else if err == errCacheUnavailable {
+		log.Printf("Cache unavailable, computing Fib(%d) uncached", n)
+		return nil, statsPb.CacheStatus_CACHE_STATUS_BYPASS
 	} else {
 		log.Printf("Redis GET error: %v", err)
+		return nil, statsPb.CacheStatus_CACHE_STATUS_BYPASS
 	}
+	return nil, statsPb.CacheStatus_CACHE_STATUS_MISS
+}
 
-	// Cache miss → compute
-	a, b := 0, 1
-	for i := 2; i <= n; i++ {
-		a, b = b, a+b
+// computeFib calculates Fib(n) iteratively, without touching the cache.
+func computeFib(n int) *big.Int {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
 	}
-	// Store in Redis
-	if err := rdb.Set(ctx, cacheKey, b, 0).Err(); err != nil {
+	return a
+}
+
+// storeFib writes Fib(n) to the cache. Results whose encoded size exceeds
+// CACHE_MAX_ENTRY_BYTES are deliberately not cached.
+func storeFib(n int, res *big.Int) {
+	key := cacheKey(n)
+	data, err := encodeEntry(key, cacheEntry{Value: res, ComputedAt: time.Now()})
+	if err != nil {
+		log.Printf("Not caching Fib(%d): %v", n, err)
+		return
+	}
+	if err := cacheSet(key, data); err != nil && err != errCacheUnavailable {
 		log.Printf("Failed to set cache: %v", err)
 	}
-	return b
 }
 
 // main starts the Fibonacci gRPC server and connects to the Stats service.
 func main() {
 	port := os.Getenv("PORT")
 	statsUrl := os.Getenv("STATS_SERVICE_URL")
+	instanceID = os.Getenv("INSTANCE_ID")
+	if instanceID == "" {
+		instanceID, _ = os.Hostname()
+	}
 	// initialize Redis DB for caching
 	InitRedis()
 	// Connect to Stats gRPC service
@@ -170,14 +235,29 @@ func main() {
 	defer conn.Close()
 	statsClient = statsPb.NewStatsClient(conn)
 	log.Printf("Connected to Stats gRPC service on %s", statsUrl)
+	batcher = newStatsBatcher(
+		envPositiveInt("STATS_BATCH_SIZE", 100),
+		envPositiveInt("STATS_QUEUE_SIZE", 10000),
+		time.Duration(envPositiveInt("STATS_FLUSH_MS", 1000))*time.Millisecond,
+	)
+	sampler = newStatsSampler(
+		envInt("STATS_SAMPLE_ONE_IN", 1),
+		envInt("STATS_SAMPLE_PER_N_PER_SEC", 0),
+		time.Duration(envPositiveInt("STATS_SAMPLE_SLOW_MS", 10))*time.Millisecond,
+	)
+
+	// Pre-populate the cache in the background if WARMUP_* is configured
+	go warmOnStartup()
 
 	// Start Fibonacci gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		log.Fatalf("Failed to listen on :%s: %v", port, err)
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(AdminAuthInterceptor))
 	pb.RegisterFibonacciServer(grpcServer, &fibonacciServer{})
+	pb.RegisterCacheAdminServer(grpcServer, &cacheAdminServer{})
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	log.Printf("Fibonacci gRPC server running on :%s\n", port)
 
 	if err := grpcServer.Serve(lis); err != nil {
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "fibonacci-grpc/proto/fibonacci"
+	statsPb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetFibRejectsOutOfRange(t *testing.T) {
+	defer func(b *statsBatcher) { batcher = b }(batcher)
+	batcher = &statsBatcher{records: make(chan *statsPb.RecordRequest, 1)}
+
+	for _, n := range []int32{-1, -50, maxN + 1} {
+		if _, err := (&fibonacciServer{}).GetFib(context.Background(), &pb.FibonacciRequest{N: n}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetFib(%d) error = %v, want InvalidArgument", n, err)
+		}
+		rec := <-batcher.records
+		if rec.GetN() != n || rec.GetStatusCode() != int32(codes.InvalidArgument) {
+			t.Errorf("GetFib(%d) recorded n=%d with status %d, want InvalidArgument", n, rec.GetN(), rec.GetStatusCode())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	statsPb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/codes"
+)
+
+// sampleInterval is how often per-n request rates are re-measured for adaptive sampling.
+const sampleInterval = time.Second
+
+// statsSampler decides which requests are reported to the Stats service.
+// Each request is kept with probability 1/k and reported with a sample
+// weight of k, so the Stats service's scaled-up counts are unbiased:
+//   - k is at least oneIn (fixed-rate sampling);
+//   - with perNPerSec set, k is raised for each n so it reports about that
+//     many records per second, measured over the previous interval;
+//   - failed requests and requests at least slow are always reported, with weight 1.
+//
+// A nil sampler reports every request.
+type statsSampler struct {
+	oneIn      int
+	perNPerSec int
+	slow       time.Duration
+
+	mu      sync.Mutex
+	start   time.Time   // start of the current interval
+	counts  map[int]int // requests per n in the current interval
+	weights map[int]int // adaptive k per n, from the previous interval
+}
+
+// newStatsSampler returns a sampler, or nil if it would report every request.
+func newStatsSampler(oneIn, perNPerSec int, slow time.Duration) *statsSampler {
+	if oneIn <= 1 && perNPerSec <= 0 {
+		return nil
+	}
+	return &statsSampler{
+		oneIn:      max(oneIn, 1),
+		perNPerSec: perNPerSec,
+		slow:       slow,
+		start:      time.Now(),
+		counts:     make(map[int]int),
+		weights:    make(map[int]int),
+	}
+}
+
+// weight returns the sample weight to report rec with, or 0 to drop it.
+func (s *statsSampler) weight(rec *statsPb.RecordRequest) int32 {
+	if s == nil {
+		return 1
+	}
+	k := s.rate(int(rec.GetN()))
+	if rec.GetStatusCode() != int32(codes.OK) || time.Duration(rec.GetDuration()) >= s.slow {
+		return 1
+	}
+	if k > 1 && rand.IntN(k) != 0 {
+		return 0
+	}
+	return int32(k)
+}
+
+// rate counts a request for n and returns the 1-in-k rate to sample it at.
+func (s *statsSampler) rate(n int) int {
+	if s.perNPerSec <= 0 {
+		return s.oneIn
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elapsed := time.Since(s.start); elapsed >= sampleInterval {
+		budget := float64(s.perNPerSec) * elapsed.Seconds()
+		clear(s.weights)
+		for n, count := range s.counts {
+			if k := int(float64(count)/budget + 0.999); k > 1 {
+				s.weights[n] = k
+			}
+		}
+		clear(s.counts)
+		s.start = time.Now()
+	}
+	s.counts[n]++
+	return max(s.oneIn, s.weights[n])
+}
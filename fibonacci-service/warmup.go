@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "fibonacci-grpc/proto/fibonacci"
+	statsPb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultWarmRate is how many entries per second a warm-up computes when no rate is configured.
+const defaultWarmRate = 20
+
+// maxWarmRate bounds the warm-up rate, keeping the tick interval well above zero.
+// There are fewer than a hundred cacheable entries, so it is never the bottleneck.
+const maxWarmRate = 10000
+
+// warmPlan describes which values of 'n' to pre-compute.
+type warmPlan struct {
+	from, to     int // inclusive range; ignored when from > to
+	topRequested int // number of most-requested 'n' to fetch from the Stats service
+	rate         int // entries per second
+}
+
+// warmPlanFromEnv reads the startup warm-up configuration:
+// WARMUP_RANGE ("from-to"), WARMUP_TOP_REQUESTED (K) and WARMUP_RATE (entries/sec).
+func warmPlanFromEnv() (warmPlan, error) {
+	plan := warmPlan{from: 1, to: 0, rate: defaultWarmRate}
+	if v := os.Getenv("WARMUP_RANGE"); v != "" {
+		from, to, ok := strings.Cut(v, "-")
+		f, err1 := strconv.Atoi(strings.TrimSpace(from))
+		t, err2 := strconv.Atoi(strings.TrimSpace(to))
+		if !ok || err1 != nil || err2 != nil {
+			return plan, fmt.Errorf("invalid WARMUP_RANGE %q, want from-to", v)
+		}
+		plan.from, plan.to = f, t
+	}
+	if v := os.Getenv("WARMUP_TOP_REQUESTED"); v != "" {
+		k, err := strconv.Atoi(v)
+		if err != nil {
+			return plan, fmt.Errorf("invalid WARMUP_TOP_REQUESTED %q: %v", v, err)
+		}
+		plan.topRequested = k
+	}
+	if v := os.Getenv("WARMUP_RATE"); v != "" {
+		r, err := strconv.Atoi(v)
+		if err != nil || r <= 0 || r > maxWarmRate {
+			return plan, fmt.Errorf("invalid WARMUP_RATE %q, want 1-%d", v, maxWarmRate)
+		}
+		plan.rate = r
+	}
+	return plan, nil
+}
+
+// empty reports whether the plan has nothing to warm.
+func (p warmPlan) empty() bool {
+	return p.from > p.to && p.topRequested <= 0
+}
+
+// targets resolves the plan into a sorted, de-duplicated list of 'n' within [2, maxN].
+// Fib(0) and Fib(1) are never cached, so they are left out.
+func (p warmPlan) targets(ctx context.Context) ([]int, error) {
+	set := make(map[int]bool)
+	for n := max(p.from, 2); n <= min(p.to, maxN); n++ {
+		set[n] = true
+	}
+	if p.topRequested > 0 {
+		top, err := mostRequested(ctx, p.topRequested)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range top {
+			if n >= 2 && n <= maxN {
+				set[n] = true
+			}
+		}
+	}
+
+	ns := make([]int, 0, len(set))
+	for n := range set {
+		ns = append(ns, n)
+	}
+	sort.Ints(ns)
+	return ns, nil
+}
+
+// mostRequested asks the Stats service for the k values of 'n' with the highest request count.
+func mostRequested(ctx context.Context, k int) ([]int, error) {
+	resp, err := statsClient.GetStats(ctx, &statsPb.StatsRequest{SortBy: "count", Descending: true, Limit: int32(k)})
+	if err != nil {
+		return nil, fmt.Errorf("fetching stats: %w", err)
+	}
+	ns := make([]int, 0, k)
+	for _, s := range resp.GetFibonacciStats() {
+		ns = append(ns, int(s.GetN()))
+	}
+	return ns, nil
+}
+
+// warmCache computes and stores every 'n' in ns that isn't cached yet,
+// at most rate entries per second. It stops early if ctx is cancelled or
+// the cache becomes unavailable.
+func warmCache(ctx context.Context, ns []int, rate int) (warmed, skipped int, err error) {
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	for _, n := range ns {
+		if _, cacheStatus := lookupFib(n); cacheStatus == statsPb.CacheStatus_CACHE_STATUS_HIT {
+			skipped++
+			continue
+		}
+		if !breaker.allow() {
+			return warmed, skipped, errCacheUnavailable
+		}
+		select {
+		case <-ctx.Done():
+			return warmed, skipped, ctx.Err()
+		case <-ticker.C:
+		}
+		storeFib(n, computeFib(n))
+		warmed++
+	}
+	return warmed, skipped, nil
+}
+
+// warmOnStartup runs the warm-up configured through the environment, if any.
+func warmOnStartup() {
+	plan, err := warmPlanFromEnv()
+	if err != nil {
+		log.Printf("Skipping cache warm-up: %v", err)
+		return
+	}
+	if plan.empty() {
+		return
+	}
+
+	// The Stats service may still be starting
+	ctx := context.Background()
+	var ns []int
+	err = RetryGRPC(6, 500*time.Millisecond, func() error {
+		ns, err = plan.targets(ctx)
+		return err
+	})
+	if err != nil {
+		log.Printf("Skipping cache warm-up: %v", err)
+		return
+	}
+	log.Printf("Warming cache for %d values at %d/s", len(ns), plan.rate)
+	warmed, skipped, err := warmCache(ctx, ns, plan.rate)
+	if err != nil {
+		log.Printf("Cache warm-up stopped after %d entries: %v", warmed, err)
+		return
+	}
+	log.Printf("Cache warm-up done: %d computed, %d already cached", warmed, skipped)
+}
+
+// Warm pre-computes the requested values on demand.
+func (*cacheAdminServer) Warm(ctx context.Context, r *pb.WarmRequest) (*pb.WarmResponse, error) {
+	if err := checkCache(); err != nil {
+		return nil, err
+	}
+	plan := warmPlan{from: 1, to: 0, topRequested: int(r.GetTopRequested()), rate: int(r.GetRatePerSec())}
+	if rg := r.GetRange(); rg != nil {
+		plan.from, plan.to = int(rg.GetFrom()), int(rg.GetTo())
+	}
+	if plan.rate <= 0 {
+		plan.rate = defaultWarmRate
+	}
+	if plan.rate > maxWarmRate {
+		return nil, status.Errorf(codes.InvalidArgument, "rate_per_sec must be at most %d", maxWarmRate)
+	}
+	if plan.empty() {
+		return nil, status.Error(codes.InvalidArgument, "range or top_requested is required")
+	}
+
+	ns, err := plan.targets(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+	warmed, skipped, err := warmCache(ctx, ns, plan.rate)
+	log.Printf("Admin warm-up: %d computed, %d already cached", warmed, skipped)
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "warm-up stopped after %d entries: %v", warmed, err)
+	}
+	return &pb.WarmResponse{Warmed: int32(warmed), Skipped: int32(skipped)}, nil
+}
@@ -0,0 +1,744 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.27.2
+// source: cache_admin.proto
+
+package fibonaccipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Range is an inclusive range of 'n'.
+type Range struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int32                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"` // First 'n' in the range
+	To            int32                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`     // Last 'n' in the range
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	mi := &file_cache_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Range) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Range) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// ListCachedRangesResponse lists cached 'n' values.
+type ListCachedRangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranges        []*Range               `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`                         // Contiguous ranges of cached 'n', ascending
+	TotalKeys     int32                  `protobuf:"varint,2,opt,name=total_keys,json=totalKeys,proto3" json:"total_keys,omitempty"` // Number of cached entries
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCachedRangesResponse) Reset() {
+	*x = ListCachedRangesResponse{}
+	mi := &file_cache_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCachedRangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCachedRangesResponse) ProtoMessage() {}
+
+func (x *ListCachedRangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCachedRangesResponse.ProtoReflect.Descriptor instead.
+func (*ListCachedRangesResponse) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCachedRangesResponse) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *ListCachedRangesResponse) GetTotalKeys() int32 {
+	if x != nil {
+		return x.TotalKeys
+	}
+	return 0
+}
+
+// GetEntryRequest selects the entry to inspect.
+type GetEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"` // Fibonacci number to look up
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntryRequest) Reset() {
+	*x = GetEntryRequest{}
+	mi := &file_cache_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntryRequest) ProtoMessage() {}
+
+func (x *GetEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetEntryRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+// CacheEntry describes a single cached value.
+type CacheEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                             // Fibonacci number
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                                          // Redis key
+	Value         int64                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`                                     // Cached result (zero if invalid or larger than int64)
+	ComputedAtMs  int64                  `protobuf:"varint,4,opt,name=computed_at_ms,json=computedAtMs,proto3" json:"computed_at_ms,omitempty"` // When the value was computed, Unix milliseconds
+	TtlMs         int64                  `protobuf:"varint,5,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`                        // Remaining TTL in milliseconds, -1 if the key never expires
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`            // Memory used by the key as reported by Redis
+	Valid         bool                   `protobuf:"varint,7,opt,name=valid,proto3" json:"valid,omitempty"`                                     // False if the entry failed its integrity check
+	DecimalValue  string                 `protobuf:"bytes,8,opt,name=decimal_value,json=decimalValue,proto3" json:"decimal_value,omitempty"`    // Cached result in decimal, for values beyond int64
+	Encoding      string                 `protobuf:"bytes,9,opt,name=encoding,proto3" json:"encoding,omitempty"`                                // Payload encoding: "raw" or "zstd"
+	StoredBytes   int32                  `protobuf:"varint,10,opt,name=stored_bytes,json=storedBytes,proto3" json:"stored_bytes,omitempty"`     // Encoded entry size including header and signature
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheEntry) Reset() {
+	*x = CacheEntry{}
+	mi := &file_cache_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheEntry) ProtoMessage() {}
+
+func (x *CacheEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheEntry.ProtoReflect.Descriptor instead.
+func (*CacheEntry) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CacheEntry) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *CacheEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CacheEntry) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CacheEntry) GetComputedAtMs() int64 {
+	if x != nil {
+		return x.ComputedAtMs
+	}
+	return 0
+}
+
+func (x *CacheEntry) GetTtlMs() int64 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+func (x *CacheEntry) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *CacheEntry) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *CacheEntry) GetDecimalValue() string {
+	if x != nil {
+		return x.DecimalValue
+	}
+	return ""
+}
+
+func (x *CacheEntry) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *CacheEntry) GetStoredBytes() int32 {
+	if x != nil {
+		return x.StoredBytes
+	}
+	return 0
+}
+
+// InvalidateRequest selects the entries to delete.
+type InvalidateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*InvalidateRequest_N
+	//	*InvalidateRequest_Range
+	Target        isInvalidateRequest_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateRequest) Reset() {
+	*x = InvalidateRequest{}
+	mi := &file_cache_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateRequest) ProtoMessage() {}
+
+func (x *InvalidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateRequest) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InvalidateRequest) GetTarget() isInvalidateRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *InvalidateRequest) GetN() int32 {
+	if x != nil {
+		if x, ok := x.Target.(*InvalidateRequest_N); ok {
+			return x.N
+		}
+	}
+	return 0
+}
+
+func (x *InvalidateRequest) GetRange() *Range {
+	if x != nil {
+		if x, ok := x.Target.(*InvalidateRequest_Range); ok {
+			return x.Range
+		}
+	}
+	return nil
+}
+
+type isInvalidateRequest_Target interface {
+	isInvalidateRequest_Target()
+}
+
+type InvalidateRequest_N struct {
+	N int32 `protobuf:"varint,1,opt,name=n,proto3,oneof"` // Delete a single 'n'
+}
+
+type InvalidateRequest_Range struct {
+	Range *Range `protobuf:"bytes,2,opt,name=range,proto3,oneof"` // Delete every 'n' in the range
+}
+
+func (*InvalidateRequest_N) isInvalidateRequest_Target() {}
+
+func (*InvalidateRequest_Range) isInvalidateRequest_Target() {}
+
+// InvalidateResponse reports how many keys were deleted.
+type InvalidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"` // Number of keys removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateResponse) Reset() {
+	*x = InvalidateResponse{}
+	mi := &file_cache_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateResponse) ProtoMessage() {}
+
+func (x *InvalidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateResponse) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvalidateResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// MemoryUsageResponse summarizes the cache namespace.
+type MemoryUsageResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Keys              int32                  `protobuf:"varint,1,opt,name=keys,proto3" json:"keys,omitempty"`                                                    // Number of cached entries
+	Bytes             int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`                                                  // Sum of MEMORY USAGE over those entries
+	RedisUsedMemory   int64                  `protobuf:"varint,3,opt,name=redis_used_memory,json=redisUsedMemory,proto3" json:"redis_used_memory,omitempty"`     // used_memory of the whole Redis instance
+	IntegrityFailures int64                  `protobuf:"varint,4,opt,name=integrity_failures,json=integrityFailures,proto3" json:"integrity_failures,omitempty"` // Entries rejected by integrity checks since startup
+	CacheAvailable    bool                   `protobuf:"varint,5,opt,name=cache_available,json=cacheAvailable,proto3" json:"cache_available,omitempty"`          // False while the circuit breaker is open
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MemoryUsageResponse) Reset() {
+	*x = MemoryUsageResponse{}
+	mi := &file_cache_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryUsageResponse) ProtoMessage() {}
+
+func (x *MemoryUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryUsageResponse.ProtoReflect.Descriptor instead.
+func (*MemoryUsageResponse) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MemoryUsageResponse) GetKeys() int32 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *MemoryUsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *MemoryUsageResponse) GetRedisUsedMemory() int64 {
+	if x != nil {
+		return x.RedisUsedMemory
+	}
+	return 0
+}
+
+func (x *MemoryUsageResponse) GetIntegrityFailures() int64 {
+	if x != nil {
+		return x.IntegrityFailures
+	}
+	return 0
+}
+
+func (x *MemoryUsageResponse) GetCacheAvailable() bool {
+	if x != nil {
+		return x.CacheAvailable
+	}
+	return false
+}
+
+// WarmRequest selects what to pre-compute. Range and top_requested may be combined.
+type WarmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         *Range                 `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`                                    // Inclusive range of 'n' to warm
+	TopRequested  int32                  `protobuf:"varint,2,opt,name=top_requested,json=topRequested,proto3" json:"top_requested,omitempty"` // Also warm the K most-requested 'n' from the Stats service
+	RatePerSec    int32                  `protobuf:"varint,3,opt,name=rate_per_sec,json=ratePerSec,proto3" json:"rate_per_sec,omitempty"`     // Maximum entries computed per second (0 = service default, at most 10000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmRequest) Reset() {
+	*x = WarmRequest{}
+	mi := &file_cache_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmRequest) ProtoMessage() {}
+
+func (x *WarmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmRequest.ProtoReflect.Descriptor instead.
+func (*WarmRequest) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WarmRequest) GetRange() *Range {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *WarmRequest) GetTopRequested() int32 {
+	if x != nil {
+		return x.TopRequested
+	}
+	return 0
+}
+
+func (x *WarmRequest) GetRatePerSec() int32 {
+	if x != nil {
+		return x.RatePerSec
+	}
+	return 0
+}
+
+// WarmResponse summarizes a warm-up run.
+type WarmResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warmed        int32                  `protobuf:"varint,1,opt,name=warmed,proto3" json:"warmed,omitempty"`   // Entries computed and stored
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // Entries that were already cached
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmResponse) Reset() {
+	*x = WarmResponse{}
+	mi := &file_cache_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmResponse) ProtoMessage() {}
+
+func (x *WarmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmResponse.ProtoReflect.Descriptor instead.
+func (*WarmResponse) Descriptor() ([]byte, []int) {
+	return file_cache_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WarmResponse) GetWarmed() int32 {
+	if x != nil {
+		return x.Warmed
+	}
+	return 0
+}
+
+func (x *WarmResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+var File_cache_admin_proto protoreflect.FileDescriptor
+
+const file_cache_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11cache_admin.proto\x12\tfibonacci\x1a\x1bgoogle/protobuf/empty.proto\"+\n" +
+	"\x05Range\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x05R\x02to\"c\n" +
+	"\x18ListCachedRangesResponse\x12(\n" +
+	"\x06ranges\x18\x01 \x03(\v2\x10.fibonacci.RangeR\x06ranges\x12\x1d\n" +
+	"\n" +
+	"total_keys\x18\x02 \x01(\x05R\ttotalKeys\"\x1f\n" +
+	"\x0fGetEntryRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\"\x98\x02\n" +
+	"\n" +
+	"CacheEntry\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x03R\x05value\x12$\n" +
+	"\x0ecomputed_at_ms\x18\x04 \x01(\x03R\fcomputedAtMs\x12\x15\n" +
+	"\x06ttl_ms\x18\x05 \x01(\x03R\x05ttlMs\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\x12\x14\n" +
+	"\x05valid\x18\a \x01(\bR\x05valid\x12#\n" +
+	"\rdecimal_value\x18\b \x01(\tR\fdecimalValue\x12\x1a\n" +
+	"\bencoding\x18\t \x01(\tR\bencoding\x12!\n" +
+	"\fstored_bytes\x18\n" +
+	" \x01(\x05R\vstoredBytes\"W\n" +
+	"\x11InvalidateRequest\x12\x0e\n" +
+	"\x01n\x18\x01 \x01(\x05H\x00R\x01n\x12(\n" +
+	"\x05range\x18\x02 \x01(\v2\x10.fibonacci.RangeH\x00R\x05rangeB\b\n" +
+	"\x06target\".\n" +
+	"\x12InvalidateResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\"\xc3\x01\n" +
+	"\x13MemoryUsageResponse\x12\x12\n" +
+	"\x04keys\x18\x01 \x01(\x05R\x04keys\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12*\n" +
+	"\x11redis_used_memory\x18\x03 \x01(\x03R\x0fredisUsedMemory\x12-\n" +
+	"\x12integrity_failures\x18\x04 \x01(\x03R\x11integrityFailures\x12'\n" +
+	"\x0fcache_available\x18\x05 \x01(\bR\x0ecacheAvailable\"|\n" +
+	"\vWarmRequest\x12&\n" +
+	"\x05range\x18\x01 \x01(\v2\x10.fibonacci.RangeR\x05range\x12#\n" +
+	"\rtop_requested\x18\x02 \x01(\x05R\ftopRequested\x12 \n" +
+	"\frate_per_sec\x18\x03 \x01(\x05R\n" +
+	"ratePerSec\"@\n" +
+	"\fWarmResponse\x12\x16\n" +
+	"\x06warmed\x18\x01 \x01(\x05R\x06warmed\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped2\xaa\x03\n" +
+	"\n" +
+	"CacheAdmin\x12O\n" +
+	"\x10ListCachedRanges\x12\x16.google.protobuf.Empty\x1a#.fibonacci.ListCachedRangesResponse\x12=\n" +
+	"\bGetEntry\x12\x1a.fibonacci.GetEntryRequest\x1a\x15.fibonacci.CacheEntry\x12I\n" +
+	"\n" +
+	"Invalidate\x12\x1c.fibonacci.InvalidateRequest\x1a\x1d.fibonacci.InvalidateResponse\x12>\n" +
+	"\x05Flush\x12\x16.google.protobuf.Empty\x1a\x1d.fibonacci.InvalidateResponse\x12H\n" +
+	"\x0eGetMemoryUsage\x12\x16.google.protobuf.Empty\x1a\x1e.fibonacci.MemoryUsageResponse\x127\n" +
+	"\x04Warm\x12\x16.fibonacci.WarmRequest\x1a\x17.fibonacci.WarmResponseB,Z*fibonacci-grpc/proto/fibonacci;fibonaccipbb\x06proto3"
+
+var (
+	file_cache_admin_proto_rawDescOnce sync.Once
+	file_cache_admin_proto_rawDescData []byte
+)
+
+func file_cache_admin_proto_rawDescGZIP() []byte {
+	file_cache_admin_proto_rawDescOnce.Do(func() {
+		file_cache_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cache_admin_proto_rawDesc), len(file_cache_admin_proto_rawDesc)))
+	})
+	return file_cache_admin_proto_rawDescData
+}
+
+var file_cache_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_cache_admin_proto_goTypes = []any{
+	(*Range)(nil),                    // 0: fibonacci.Range
+	(*ListCachedRangesResponse)(nil), // 1: fibonacci.ListCachedRangesResponse
+	(*GetEntryRequest)(nil),          // 2: fibonacci.GetEntryRequest
+	(*CacheEntry)(nil),               // 3: fibonacci.CacheEntry
+	(*InvalidateRequest)(nil),        // 4: fibonacci.InvalidateRequest
+	(*InvalidateResponse)(nil),       // 5: fibonacci.InvalidateResponse
+	(*MemoryUsageResponse)(nil),      // 6: fibonacci.MemoryUsageResponse
+	(*WarmRequest)(nil),              // 7: fibonacci.WarmRequest
+	(*WarmResponse)(nil),             // 8: fibonacci.WarmResponse
+	(*emptypb.Empty)(nil),            // 9: google.protobuf.Empty
+}
+var file_cache_admin_proto_depIdxs = []int32{
+	0, // 0: fibonacci.ListCachedRangesResponse.ranges:type_name -> fibonacci.Range
+	0, // 1: fibonacci.InvalidateRequest.range:type_name -> fibonacci.Range
+	0, // 2: fibonacci.WarmRequest.range:type_name -> fibonacci.Range
+	9, // 3: fibonacci.CacheAdmin.ListCachedRanges:input_type -> google.protobuf.Empty
+	2, // 4: fibonacci.CacheAdmin.GetEntry:input_type -> fibonacci.GetEntryRequest
+	4, // 5: fibonacci.CacheAdmin.Invalidate:input_type -> fibonacci.InvalidateRequest
+	9, // 6: fibonacci.CacheAdmin.Flush:input_type -> google.protobuf.Empty
+	9, // 7: fibonacci.CacheAdmin.GetMemoryUsage:input_type -> google.protobuf.Empty
+	7, // 8: fibonacci.CacheAdmin.Warm:input_type -> fibonacci.WarmRequest
+	1, // 9: fibonacci.CacheAdmin.ListCachedRanges:output_type -> fibonacci.ListCachedRangesResponse
+	3, // 10: fibonacci.CacheAdmin.GetEntry:output_type -> fibonacci.CacheEntry
+	5, // 11: fibonacci.CacheAdmin.Invalidate:output_type -> fibonacci.InvalidateResponse
+	5, // 12: fibonacci.CacheAdmin.Flush:output_type -> fibonacci.InvalidateResponse
+	6, // 13: fibonacci.CacheAdmin.GetMemoryUsage:output_type -> fibonacci.MemoryUsageResponse
+	8, // 14: fibonacci.CacheAdmin.Warm:output_type -> fibonacci.WarmResponse
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_cache_admin_proto_init() }
+func file_cache_admin_proto_init() {
+	if File_cache_admin_proto != nil {
+		return
+	}
+	file_cache_admin_proto_msgTypes[4].OneofWrappers = []any{
+		(*InvalidateRequest_N)(nil),
+		(*InvalidateRequest_Range)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cache_admin_proto_rawDesc), len(file_cache_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cache_admin_proto_goTypes,
+		DependencyIndexes: file_cache_admin_proto_depIdxs,
+		MessageInfos:      file_cache_admin_proto_msgTypes,
+	}.Build()
+	File_cache_admin_proto = out.File
+	file_cache_admin_proto_goTypes = nil
+	file_cache_admin_proto_depIdxs = nil
+}
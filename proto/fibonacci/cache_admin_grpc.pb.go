@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.2
+// source: cache_admin.proto
+
+package fibonaccipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CacheAdmin_ListCachedRanges_FullMethodName = "/fibonacci.CacheAdmin/ListCachedRanges"
+	CacheAdmin_GetEntry_FullMethodName         = "/fibonacci.CacheAdmin/GetEntry"
+	CacheAdmin_Invalidate_FullMethodName       = "/fibonacci.CacheAdmin/Invalidate"
+	CacheAdmin_Flush_FullMethodName            = "/fibonacci.CacheAdmin/Flush"
+	CacheAdmin_GetMemoryUsage_FullMethodName   = "/fibonacci.CacheAdmin/GetMemoryUsage"
+	CacheAdmin_Warm_FullMethodName             = "/fibonacci.CacheAdmin/Warm"
+)
+
+// CacheAdminClient is the client API for CacheAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CacheAdmin inspects and manages the Fibonacci service's Redis cache.
+// Every call must carry "authorization: Bearer <ADMIN_TOKEN>" metadata.
+type CacheAdminClient interface {
+	// ListCachedRanges returns the cached values of 'n' as contiguous ranges.
+	ListCachedRanges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCachedRangesResponse, error)
+	// GetEntry returns the stored value and metadata for a single 'n'.
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*CacheEntry, error)
+	// Invalidate deletes the cached value for a single 'n' or a range of 'n'.
+	Invalidate(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*InvalidateResponse, error)
+	// Flush deletes every key in the Fibonacci cache namespace.
+	Flush(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InvalidateResponse, error)
+	// GetMemoryUsage reports how much Redis memory the cache namespace uses.
+	GetMemoryUsage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MemoryUsageResponse, error)
+	// Warm computes and caches a range of 'n' and/or the most-requested 'n'
+	// reported by the Stats service, rate-limited to protect live traffic.
+	Warm(ctx context.Context, in *WarmRequest, opts ...grpc.CallOption) (*WarmResponse, error)
+}
+
+type cacheAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCacheAdminClient(cc grpc.ClientConnInterface) CacheAdminClient {
+	return &cacheAdminClient{cc}
+}
+
+func (c *cacheAdminClient) ListCachedRanges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCachedRangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCachedRangesResponse)
+	err := c.cc.Invoke(ctx, CacheAdmin_ListCachedRanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheAdminClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*CacheEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CacheEntry)
+	err := c.cc.Invoke(ctx, CacheAdmin_GetEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheAdminClient) Invalidate(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*InvalidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateResponse)
+	err := c.cc.Invoke(ctx, CacheAdmin_Invalidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheAdminClient) Flush(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InvalidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateResponse)
+	err := c.cc.Invoke(ctx, CacheAdmin_Flush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheAdminClient) GetMemoryUsage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MemoryUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemoryUsageResponse)
+	err := c.cc.Invoke(ctx, CacheAdmin_GetMemoryUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheAdminClient) Warm(ctx context.Context, in *WarmRequest, opts ...grpc.CallOption) (*WarmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WarmResponse)
+	err := c.cc.Invoke(ctx, CacheAdmin_Warm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CacheAdminServer is the server API for CacheAdmin service.
+// All implementations must embed UnimplementedCacheAdminServer
+// for forward compatibility.
+//
+// CacheAdmin inspects and manages the Fibonacci service's Redis cache.
+// Every call must carry "authorization: Bearer <ADMIN_TOKEN>" metadata.
+type CacheAdminServer interface {
+	// ListCachedRanges returns the cached values of 'n' as contiguous ranges.
+	ListCachedRanges(context.Context, *emptypb.Empty) (*ListCachedRangesResponse, error)
+	// GetEntry returns the stored value and metadata for a single 'n'.
+	GetEntry(context.Context, *GetEntryRequest) (*CacheEntry, error)
+	// Invalidate deletes the cached value for a single 'n' or a range of 'n'.
+	Invalidate(context.Context, *InvalidateRequest) (*InvalidateResponse, error)
+	// Flush deletes every key in the Fibonacci cache namespace.
+	Flush(context.Context, *emptypb.Empty) (*InvalidateResponse, error)
+	// GetMemoryUsage reports how much Redis memory the cache namespace uses.
+	GetMemoryUsage(context.Context, *emptypb.Empty) (*MemoryUsageResponse, error)
+	// Warm computes and caches a range of 'n' and/or the most-requested 'n'
+	// reported by the Stats service, rate-limited to protect live traffic.
+	Warm(context.Context, *WarmRequest) (*WarmResponse, error)
+	mustEmbedUnimplementedCacheAdminServer()
+}
+
+// UnimplementedCacheAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCacheAdminServer struct{}
+
+func (UnimplementedCacheAdminServer) ListCachedRanges(context.Context, *emptypb.Empty) (*ListCachedRangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCachedRanges not implemented")
+}
+func (UnimplementedCacheAdminServer) GetEntry(context.Context, *GetEntryRequest) (*CacheEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntry not implemented")
+}
+func (UnimplementedCacheAdminServer) Invalidate(context.Context, *InvalidateRequest) (*InvalidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invalidate not implemented")
+}
+func (UnimplementedCacheAdminServer) Flush(context.Context, *emptypb.Empty) (*InvalidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
+}
+func (UnimplementedCacheAdminServer) GetMemoryUsage(context.Context, *emptypb.Empty) (*MemoryUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemoryUsage not implemented")
+}
+func (UnimplementedCacheAdminServer) Warm(context.Context, *WarmRequest) (*WarmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warm not implemented")
+}
+func (UnimplementedCacheAdminServer) mustEmbedUnimplementedCacheAdminServer() {}
+func (UnimplementedCacheAdminServer) testEmbeddedByValue()                    {}
+
+// UnsafeCacheAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CacheAdminServer will
+// result in compilation errors.
+type UnsafeCacheAdminServer interface {
+	mustEmbedUnimplementedCacheAdminServer()
+}
+
+func RegisterCacheAdminServer(s grpc.ServiceRegistrar, srv CacheAdminServer) {
+	// If the following call pancis, it indicates UnimplementedCacheAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CacheAdmin_ServiceDesc, srv)
+}
+
+func _CacheAdmin_ListCachedRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).ListCachedRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_ListCachedRanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).ListCachedRanges(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheAdmin_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_GetEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheAdmin_Invalidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).Invalidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_Invalidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).Invalidate(ctx, req.(*InvalidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheAdmin_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_Flush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).Flush(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheAdmin_GetMemoryUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).GetMemoryUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_GetMemoryUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).GetMemoryUsage(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheAdmin_Warm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheAdminServer).Warm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CacheAdmin_Warm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheAdminServer).Warm(ctx, req.(*WarmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CacheAdmin_ServiceDesc is the grpc.ServiceDesc for CacheAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CacheAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fibonacci.CacheAdmin",
+	HandlerType: (*CacheAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCachedRanges",
+			Handler:    _CacheAdmin_ListCachedRanges_Handler,
+		},
+		{
+			MethodName: "GetEntry",
+			Handler:    _CacheAdmin_GetEntry_Handler,
+		},
+		{
+			MethodName: "Invalidate",
+			Handler:    _CacheAdmin_Invalidate_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _CacheAdmin_Flush_Handler,
+		},
+		{
+			MethodName: "GetMemoryUsage",
+			Handler:    _CacheAdmin_GetMemoryUsage_Handler,
+		},
+		{
+			MethodName: "Warm",
+			Handler:    _CacheAdmin_Warm_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cache_admin.proto",
+}
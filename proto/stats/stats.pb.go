@@ -4,47 +4,1441 @@
 // 	protoc        v5.27.2
 // source: stats.proto
 
+// Package stats provides a gRPC service for recording and retrieving
+// statistics of Fibonacci number requests.
+
 package statspb
 
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CacheStatus describes how the Fibonacci service's cache served a request.
+type CacheStatus int32
+
+const (
+	CacheStatus_CACHE_STATUS_UNKNOWN CacheStatus = 0 // Not reported
+	CacheStatus_CACHE_STATUS_HIT     CacheStatus = 1 // Served from the cache
+	CacheStatus_CACHE_STATUS_MISS    CacheStatus = 2 // Computed and stored
+	CacheStatus_CACHE_STATUS_BYPASS  CacheStatus = 3 // Cache not consulted (unavailable or trivial n)
+)
+
+// Enum value maps for CacheStatus.
+var (
+	CacheStatus_name = map[int32]string{
+		0: "CACHE_STATUS_UNKNOWN",
+		1: "CACHE_STATUS_HIT",
+		2: "CACHE_STATUS_MISS",
+		3: "CACHE_STATUS_BYPASS",
+	}
+	CacheStatus_value = map[string]int32{
+		"CACHE_STATUS_UNKNOWN": 0,
+		"CACHE_STATUS_HIT":     1,
+		"CACHE_STATUS_MISS":    2,
+		"CACHE_STATUS_BYPASS":  3,
+	}
+)
+
+func (x CacheStatus) Enum() *CacheStatus {
+	p := new(CacheStatus)
+	*p = x
+	return p
+}
+
+func (x CacheStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CacheStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_proto_enumTypes[0].Descriptor()
+}
+
+func (CacheStatus) Type() protoreflect.EnumType {
+	return &file_stats_proto_enumTypes[0]
+}
+
+func (x CacheStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CacheStatus.Descriptor instead.
+func (CacheStatus) EnumDescriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{0}
+}
+
+// AlertState is where an alert is in its lifecycle.
+type AlertState int32
+
+const (
+	AlertState_ALERT_STATE_PENDING AlertState = 0 // Condition holds, but not yet for the rule's "for" duration
+	AlertState_ALERT_STATE_FIRING  AlertState = 1 // Condition has held long enough; the webhook was notified
+)
+
+// Enum value maps for AlertState.
+var (
+	AlertState_name = map[int32]string{
+		0: "ALERT_STATE_PENDING",
+		1: "ALERT_STATE_FIRING",
+	}
+	AlertState_value = map[string]int32{
+		"ALERT_STATE_PENDING": 0,
+		"ALERT_STATE_FIRING":  1,
+	}
+)
+
+func (x AlertState) Enum() *AlertState {
+	p := new(AlertState)
+	*p = x
+	return p
+}
+
+func (x AlertState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertState) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_proto_enumTypes[1].Descriptor()
+}
+
+func (AlertState) Type() protoreflect.EnumType {
+	return &file_stats_proto_enumTypes[1]
+}
+
+func (x AlertState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertState.Descriptor instead.
+func (AlertState) EnumDescriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{1}
+}
+
+// StatsRequest selects which statistics GetStats returns.
+//
+// Dimensions usable in group_by and filters are "n", "cache", "algorithm",
+// "status", "instance" and "client".
+type StatsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Window           string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`                                                                             // Rolling window: "1m", "5m", "1h", "24h"; empty for all-time
+	GroupBy          []string               `protobuf:"bytes,2,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`                                                            // Dimensions to break stats down by (default: ["n"])
+	Filters          map[string]string      `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only count records whose dimension equals the value
+	MinN             *int32                 `protobuf:"varint,4,opt,name=min_n,json=minN,proto3,oneof" json:"min_n,omitempty"`                                                              // Only include n >= min_n
+	MaxN             *int32                 `protobuf:"varint,5,opt,name=max_n,json=maxN,proto3,oneof" json:"max_n,omitempty"`                                                              // Only include n <= max_n
+	SortBy           string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                                                               // "n" (default), "count", "latency" (average) or "p99"
+	Descending       bool                   `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`                                                                    // Reverse the sort order
+	Limit            int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`                                                                              // Keep only the first limit rows after sorting (top-K), 0 for all
+	PageSize         int32                  `protobuf:"varint,9,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                                        // Rows per page, 0 for all remaining rows
+	PageToken        string                 `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                                                     // next_page_token from the previous page
+	IncludeInstances bool                   `protobuf:"varint,11,opt,name=include_instances,json=includeInstances,proto3" json:"include_instances,omitempty"`                               // Also break the matching records down per Fibonacci instance
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_stats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatsRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetMinN() int32 {
+	if x != nil && x.MinN != nil {
+		return *x.MinN
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetMaxN() int32 {
+	if x != nil && x.MaxN != nil {
+		return *x.MaxN
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *StatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *StatsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetIncludeInstances() bool {
+	if x != nil {
+		return x.IncludeInstances
+	}
+	return false
+}
+
+// StatsResponse represents aggregated statistics for Fibonacci requests.
+type StatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalRequests     int32                  `protobuf:"varint,1,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`              // Total number of requests received
+	FibonacciStats    []*FibonacciStat       `protobuf:"bytes,2,rep,name=fibonacci_stats,json=fibonacciStats,proto3" json:"fibonacci_stats,omitempty"`            // Per-number statistics
+	Window            string                 `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`                                                  // Window the statistics cover, empty for all-time
+	Qps               float64                `protobuf:"fixed64,4,opt,name=qps,proto3" json:"qps,omitempty"`                                                      // Requests per second over the window, zero for all-time
+	TotalErrors       int32                  `protobuf:"varint,5,opt,name=total_errors,json=totalErrors,proto3" json:"total_errors,omitempty"`                    // Requests that failed with a non-OK status code
+	ErrorRate         float64                `protobuf:"fixed64,6,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`                         // Fraction of requests that failed
+	NextPageToken     string                 `protobuf:"bytes,7,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`             // Token for the next page, empty on the last one
+	TotalRows         int32                  `protobuf:"varint,8,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`                          // Rows matching the request across all pages
+	Approximate       bool                   `protobuf:"varint,9,opt,name=approximate,proto3" json:"approximate,omitempty"`                                       // Some keys are tracked approximately (see FibonacciStat.approximate)
+	DuplicatesDropped int64                  `protobuf:"varint,10,opt,name=duplicates_dropped,json=duplicatesDropped,proto3" json:"duplicates_dropped,omitempty"` // Retried records dropped by this instance since it started
+	Replicas          int32                  `protobuf:"varint,11,opt,name=replicas,proto3" json:"replicas,omitempty"`                                            // Stats replicas whose records are included
+	Instances         []*InstanceStat        `protobuf:"bytes,12,rep,name=instances,proto3" json:"instances,omitempty"`                                           // Per-instance breakdown, if include_instances was set
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_stats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatsResponse) GetTotalRequests() int32 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetFibonacciStats() []*FibonacciStat {
+	if x != nil {
+		return x.FibonacciStats
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *StatsResponse) GetQps() float64 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetTotalErrors() int32 {
+	if x != nil {
+		return x.TotalErrors
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *StatsResponse) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *StatsResponse) GetDuplicatesDropped() int64 {
+	if x != nil {
+		return x.DuplicatesDropped
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetInstances() []*InstanceStat {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// InstanceStat summarizes the requests served by one Fibonacci instance.
+type InstanceStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      string                 `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`                          // INSTANCE_ID of the Fibonacci replica
+	Stats         *FibonacciStat         `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`                                // Counts and latency of its requests (n is unset)
+	LastSeenMs    int64                  `protobuf:"varint,3,opt,name=last_seen_ms,json=lastSeenMs,proto3" json:"last_seen_ms,omitempty"` // When it last served a request, Unix milliseconds; zero if not since the Stats service started
+	Stale         bool                   `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`                               // It has not reported for longer than STATS_INSTANCE_STALE_AFTER
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceStat) Reset() {
+	*x = InstanceStat{}
+	mi := &file_stats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceStat) ProtoMessage() {}
+
+func (x *InstanceStat) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceStat.ProtoReflect.Descriptor instead.
+func (*InstanceStat) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InstanceStat) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *InstanceStat) GetStats() *FibonacciStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *InstanceStat) GetLastSeenMs() int64 {
+	if x != nil {
+		return x.LastSeenMs
+	}
+	return 0
+}
+
+func (x *InstanceStat) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// FibonacciStat contains statistics for a single Fibonacci number.
+type FibonacciStat struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	N                  int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                                                                     // Fibonacci number requested
+	RequestCount       int32                  `protobuf:"varint,2,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`                                           // How many times it was requested
+	AverageTimeMs      float64                `protobuf:"fixed64,3,opt,name=average_time_ms,json=averageTimeMs,proto3" json:"average_time_ms,omitempty"`                                     // Average computation time in milliseconds
+	MinUs              float64                `protobuf:"fixed64,4,opt,name=min_us,json=minUs,proto3" json:"min_us,omitempty"`                                                               // Fastest computation in microseconds
+	MaxUs              float64                `protobuf:"fixed64,5,opt,name=max_us,json=maxUs,proto3" json:"max_us,omitempty"`                                                               // Slowest computation in microseconds
+	P50Us              float64                `protobuf:"fixed64,6,opt,name=p50_us,json=p50Us,proto3" json:"p50_us,omitempty"`                                                               // Median computation time in microseconds
+	P90Us              float64                `protobuf:"fixed64,7,opt,name=p90_us,json=p90Us,proto3" json:"p90_us,omitempty"`                                                               // 90th percentile in microseconds
+	P99Us              float64                `protobuf:"fixed64,8,opt,name=p99_us,json=p99Us,proto3" json:"p99_us,omitempty"`                                                               // 99th percentile in microseconds
+	P999Us             float64                `protobuf:"fixed64,9,opt,name=p999_us,json=p999Us,proto3" json:"p999_us,omitempty"`                                                            // 99.9th percentile in microseconds
+	Qps                float64                `protobuf:"fixed64,10,opt,name=qps,proto3" json:"qps,omitempty"`                                                                               // Requests per second for this number over the window
+	Labels             map[string]string      `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Value of every grouped dimension for this row
+	AverageResultBytes float64                `protobuf:"fixed64,12,opt,name=average_result_bytes,json=averageResultBytes,proto3" json:"average_result_bytes,omitempty"`                     // Average size of the computed result
+	ErrorCount         int32                  `protobuf:"varint,13,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`                                                // Requests that failed with a non-OK status code
+	ErrorRate          float64                `protobuf:"fixed64,14,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`                                                  // Fraction of requests that failed
+	EstimatedCount     int32                  `protobuf:"varint,15,opt,name=estimated_count,json=estimatedCount,proto3" json:"estimated_count,omitempty"`                                    // request_count plus requests recorded before exact tracking began (upper bound)
+	Approximate        bool                   `protobuf:"varint,16,opt,name=approximate,proto3" json:"approximate,omitempty"`                                                                // Counts are estimates, or this is the n = -1 overflow row
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FibonacciStat) Reset() {
+	*x = FibonacciStat{}
+	mi := &file_stats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FibonacciStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FibonacciStat) ProtoMessage() {}
+
+func (x *FibonacciStat) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FibonacciStat.ProtoReflect.Descriptor instead.
+func (*FibonacciStat) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FibonacciStat) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetRequestCount() int32 {
+	if x != nil {
+		return x.RequestCount
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetAverageTimeMs() float64 {
+	if x != nil {
+		return x.AverageTimeMs
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetMinUs() float64 {
+	if x != nil {
+		return x.MinUs
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetMaxUs() float64 {
+	if x != nil {
+		return x.MaxUs
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetP50Us() float64 {
+	if x != nil {
+		return x.P50Us
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetP90Us() float64 {
+	if x != nil {
+		return x.P90Us
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetP99Us() float64 {
+	if x != nil {
+		return x.P99Us
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetP999Us() float64 {
+	if x != nil {
+		return x.P999Us
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetQps() float64 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *FibonacciStat) GetAverageResultBytes() float64 {
+	if x != nil {
+		return x.AverageResultBytes
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetEstimatedCount() int32 {
+	if x != nil {
+		return x.EstimatedCount
+	}
+	return 0
+}
+
+func (x *FibonacciStat) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+// RecordRequest represents a request to record a Fibonacci computation.
+type RecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                                               // Fibonacci number requested
+	Duration      int64                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`                                                 // Computation duration in nanoseconds
+	CacheStatus   CacheStatus            `protobuf:"varint,3,opt,name=cache_status,json=cacheStatus,proto3,enum=stats.CacheStatus" json:"cache_status,omitempty"` // Cache hit, miss or bypass
+	Algorithm     string                 `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                                // How the result was produced, e.g. "iterative"
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`                           // gRPC status code returned to the caller (0 = OK)
+	InstanceId    string                 `protobuf:"bytes,6,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`                            // Fibonacci service instance that served the request
+	ClientId      string                 `protobuf:"bytes,7,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                  // Client or tenant that made the request
+	TimestampMs   int64                  `protobuf:"varint,8,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`                        // When the request was served, Unix milliseconds
+	ResultSize    int32                  `protobuf:"varint,9,opt,name=result_size,json=resultSize,proto3" json:"result_size,omitempty"`                           // Size of the result in bytes
+	EventId       string                 `protobuf:"bytes,10,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                                    // Unique per request; retries reuse it so the record is counted once
+	RequestId     string                 `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                              // X-Request-ID the request arrived with at the gateway, if any
+	SampleWeight  int32                  `protobuf:"varint,12,opt,name=sample_weight,json=sampleWeight,proto3" json:"sample_weight,omitempty"`                    // Requests this record stands for when the Fibonacci service samples; 0 means 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordRequest) Reset() {
+	*x = RecordRequest{}
+	mi := &file_stats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordRequest) ProtoMessage() {}
+
+func (x *RecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordRequest.ProtoReflect.Descriptor instead.
+func (*RecordRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *RecordRequest) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *RecordRequest) GetCacheStatus() CacheStatus {
+	if x != nil {
+		return x.CacheStatus
+	}
+	return CacheStatus_CACHE_STATUS_UNKNOWN
+}
+
+func (x *RecordRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *RecordRequest) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *RecordRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *RecordRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RecordRequest) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *RecordRequest) GetResultSize() int32 {
+	if x != nil {
+		return x.ResultSize
+	}
+	return 0
+}
+
+func (x *RecordRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RecordRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RecordRequest) GetSampleWeight() int32 {
+	if x != nil {
+		return x.SampleWeight
+	}
+	return 0
+}
+
+// RecordBatchRequest carries several records.
+type RecordBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*RecordRequest       `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` // Computations to record
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBatchRequest) Reset() {
+	*x = RecordBatchRequest{}
+	mi := &file_stats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBatchRequest) ProtoMessage() {}
+
+func (x *RecordBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBatchRequest.ProtoReflect.Descriptor instead.
+func (*RecordBatchRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordBatchRequest) GetRecords() []*RecordRequest {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// RecordBatchResponse reports the outcome of a batch or stream.
+type RecordBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      int32                  `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`     // Records stored
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`         // Records that could not be stored
+	Duplicates    int32                  `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"` // Records dropped because their event_id was already recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordBatchResponse) Reset() {
+	*x = RecordBatchResponse{}
+	mi := &file_stats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordBatchResponse) ProtoMessage() {}
+
+func (x *RecordBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordBatchResponse.ProtoReflect.Descriptor instead.
+func (*RecordBatchResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecordBatchResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *RecordBatchResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RecordBatchResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+// RecordResponse indicates whether recording the request succeeded.
+type RecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`     // True if recording succeeded
+	Duplicate     bool                   `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // The event_id was already recorded, so nothing was counted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordResponse) Reset() {
+	*x = RecordResponse{}
+	mi := &file_stats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordResponse) ProtoMessage() {}
+
+func (x *RecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordResponse.ProtoReflect.Descriptor instead.
+func (*RecordResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+// QueryStatsRequest selects a time range and resolution for QueryStats.
+type QueryStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromMs        int64                  `protobuf:"varint,1,opt,name=from_ms,json=fromMs,proto3" json:"from_ms,omitempty"` // Start of the range, Unix milliseconds (default: one hour before to_ms)
+	ToMs          int64                  `protobuf:"varint,2,opt,name=to_ms,json=toMs,proto3" json:"to_ms,omitempty"`       // End of the range, Unix milliseconds (default: now)
+	StepMs        int64                  `protobuf:"varint,3,opt,name=step_ms,json=stepMs,proto3" json:"step_ms,omitempty"` // Bucket width in milliseconds, rounded up to the storage resolution (0 = automatic)
+	N             []int32                `protobuf:"varint,4,rep,packed,name=n,proto3" json:"n,omitempty"`                  // Return one series per listed number; empty for a single series over all numbers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStatsRequest) Reset() {
+	*x = QueryStatsRequest{}
+	mi := &file_stats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStatsRequest) ProtoMessage() {}
+
+func (x *QueryStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStatsRequest.ProtoReflect.Descriptor instead.
+func (*QueryStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryStatsRequest) GetFromMs() int64 {
+	if x != nil {
+		return x.FromMs
+	}
+	return 0
+}
+
+func (x *QueryStatsRequest) GetToMs() int64 {
+	if x != nil {
+		return x.ToMs
+	}
+	return 0
+}
+
+func (x *QueryStatsRequest) GetStepMs() int64 {
+	if x != nil {
+		return x.StepMs
+	}
+	return 0
+}
+
+func (x *QueryStatsRequest) GetN() []int32 {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+// QueryStatsResponse holds the requested time series.
+type QueryStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StepMs        int64                  `protobuf:"varint,1,opt,name=step_ms,json=stepMs,proto3" json:"step_ms,omitempty"` // Bucket width actually used
+	Series        []*TimeSeries          `protobuf:"bytes,2,rep,name=series,proto3" json:"series,omitempty"`                // One series per requested number, or one aggregate series
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStatsResponse) Reset() {
+	*x = QueryStatsResponse{}
+	mi := &file_stats_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStatsResponse) ProtoMessage() {}
+
+func (x *QueryStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStatsResponse.ProtoReflect.Descriptor instead.
+func (*QueryStatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueryStatsResponse) GetStepMs() int64 {
+	if x != nil {
+		return x.StepMs
+	}
+	return 0
+}
+
+func (x *QueryStatsResponse) GetSeries() []*TimeSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// TimeSeries is the history of one number, or of all numbers combined.
+type TimeSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                 // Fibonacci number, unset for the aggregate series
+	Aggregate     bool                   `protobuf:"varint,2,opt,name=aggregate,proto3" json:"aggregate,omitempty"` // True if the series combines every number
+	Points        []*SeriesPoint         `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`        // One point per step, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeSeries) Reset() {
+	*x = TimeSeries{}
+	mi := &file_stats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSeries) ProtoMessage() {}
+
+func (x *TimeSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeries.ProtoReflect.Descriptor instead.
+func (*TimeSeries) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TimeSeries) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *TimeSeries) GetAggregate() bool {
+	if x != nil {
+		return x.Aggregate
+	}
+	return false
+}
+
+func (x *TimeSeries) GetPoints() []*SeriesPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// SeriesPoint summarizes the requests in one time bucket.
+type SeriesPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimestampMs   int64                  `protobuf:"varint,1,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Start of the bucket, Unix milliseconds
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                                // Requests in the bucket
+	Qps           float64                `protobuf:"fixed64,3,opt,name=qps,proto3" json:"qps,omitempty"`                                   // Requests per second in the bucket
+	AverageUs     float64                `protobuf:"fixed64,4,opt,name=average_us,json=averageUs,proto3" json:"average_us,omitempty"`      // Average computation time in microseconds
+	P50Us         float64                `protobuf:"fixed64,5,opt,name=p50_us,json=p50Us,proto3" json:"p50_us,omitempty"`                  // Median computation time in microseconds
+	P90Us         float64                `protobuf:"fixed64,6,opt,name=p90_us,json=p90Us,proto3" json:"p90_us,omitempty"`                  // 90th percentile in microseconds
+	P99Us         float64                `protobuf:"fixed64,7,opt,name=p99_us,json=p99Us,proto3" json:"p99_us,omitempty"`                  // 99th percentile in microseconds
+	MaxUs         float64                `protobuf:"fixed64,8,opt,name=max_us,json=maxUs,proto3" json:"max_us,omitempty"`                  // Slowest computation in microseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesPoint) Reset() {
+	*x = SeriesPoint{}
+	mi := &file_stats_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesPoint) ProtoMessage() {}
+
+func (x *SeriesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesPoint.ProtoReflect.Descriptor instead.
+func (*SeriesPoint) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SeriesPoint) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetQps() float64 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetAverageUs() float64 {
+	if x != nil {
+		return x.AverageUs
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetP50Us() float64 {
+	if x != nil {
+		return x.P50Us
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetP90Us() float64 {
+	if x != nil {
+		return x.P90Us
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetP99Us() float64 {
+	if x != nil {
+		return x.P99Us
+	}
+	return 0
+}
+
+func (x *SeriesPoint) GetMaxUs() float64 {
+	if x != nil {
+		return x.MaxUs
+	}
+	return 0
+}
+
+// WatchStatsRequest configures a WatchStats stream.
+type WatchStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntervalMs    int64                  `protobuf:"varint,1,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"` // Send a snapshot this often; 0 streams every recorded request instead
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`                            // Window for snapshots, as in StatsRequest
+	N             []int32                `protobuf:"varint,3,rep,packed,name=n,proto3" json:"n,omitempty"`                              // Only report these numbers; empty for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStatsRequest) Reset() {
+	*x = WatchStatsRequest{}
+	mi := &file_stats_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatsRequest) ProtoMessage() {}
+
+func (x *WatchStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatsRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchStatsRequest) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *WatchStatsRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *WatchStatsRequest) GetN() []int32 {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+// RecordedEvent is a single request recorded by the Stats service.
+type RecordedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                        // Fibonacci number requested
+	DurationUs    float64                `protobuf:"fixed64,2,opt,name=duration_us,json=durationUs,proto3" json:"duration_us,omitempty"`   // Computation time in microseconds
+	TimestampMs   int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // When it was recorded, Unix milliseconds
+	Weight        int64                  `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`                              // Requests it stands for; more than 1 if the Fibonacci service sampled it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordedEvent) Reset() {
+	*x = RecordedEvent{}
+	mi := &file_stats_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordedEvent) ProtoMessage() {}
+
+func (x *RecordedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordedEvent.ProtoReflect.Descriptor instead.
+func (*RecordedEvent) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RecordedEvent) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *RecordedEvent) GetDurationUs() float64 {
+	if x != nil {
+		return x.DurationUs
+	}
+	return 0
+}
+
+func (x *RecordedEvent) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *RecordedEvent) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// WatchStatsResponse is one message on a WatchStats stream.
+type WatchStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*WatchStatsResponse_Snapshot
+	//	*WatchStatsResponse_Event
+	Update        isWatchStatsResponse_Update `protobuf_oneof:"update"`
+	Dropped       int64                       `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"` // Events dropped for this watcher since the previous message because it fell behind
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStatsResponse) Reset() {
+	*x = WatchStatsResponse{}
+	mi := &file_stats_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatsResponse) ProtoMessage() {}
+
+func (x *WatchStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatsResponse.ProtoReflect.Descriptor instead.
+func (*WatchStatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchStatsResponse) GetUpdate() isWatchStatsResponse_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *WatchStatsResponse) GetSnapshot() *StatsResponse {
+	if x != nil {
+		if x, ok := x.Update.(*WatchStatsResponse_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *WatchStatsResponse) GetEvent() *RecordedEvent {
+	if x != nil {
+		if x, ok := x.Update.(*WatchStatsResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *WatchStatsResponse) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type isWatchStatsResponse_Update interface {
+	isWatchStatsResponse_Update()
+}
 
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
+type WatchStatsResponse_Snapshot struct {
+	Snapshot *StatsResponse `protobuf:"bytes,1,opt,name=snapshot,proto3,oneof"` // Full statistics
+}
 
-type StatsResponse struct {
+type WatchStatsResponse_Event struct {
+	Event *RecordedEvent `protobuf:"bytes,2,opt,name=event,proto3,oneof"` // A newly recorded request
+}
+
+func (*WatchStatsResponse_Snapshot) isWatchStatsResponse_Update() {}
+
+func (*WatchStatsResponse_Event) isWatchStatsResponse_Update() {}
+
+// GetAlertsRequest selects which alerts GetAlerts returns.
+type GetAlertsRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	TotalRequests  int32                  `protobuf:"varint,1,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`   // total number of requests received
-	FibonacciStats []*FibonacciStat       `protobuf:"bytes,2,rep,name=fibonacci_stats,json=fibonacciStats,proto3" json:"fibonacci_stats,omitempty"` // per-number stats
+	IncludePending bool                   `protobuf:"varint,1,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"` // Also return alerts that are not firing yet
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *StatsResponse) Reset() {
-	*x = StatsResponse{}
-	mi := &file_stats_proto_msgTypes[0]
+func (x *GetAlertsRequest) Reset() {
+	*x = GetAlertsRequest{}
+	mi := &file_stats_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatsResponse) String() string {
+func (x *GetAlertsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatsResponse) ProtoMessage() {}
+func (*GetAlertsRequest) ProtoMessage() {}
 
-func (x *StatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[0]
+func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,50 +1449,168 @@ func (x *StatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
-func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetAlertsRequest.ProtoReflect.Descriptor instead.
+func (*GetAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StatsResponse) GetTotalRequests() int32 {
+func (x *GetAlertsRequest) GetIncludePending() bool {
 	if x != nil {
-		return x.TotalRequests
+		return x.IncludePending
 	}
-	return 0
+	return false
 }
 
-func (x *StatsResponse) GetFibonacciStats() []*FibonacciStat {
+// Alert is one rule whose condition holds for one group of records.
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`                                                                               // Name of the rule
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Group the alert is for, e.g. {"n": "40"}; empty for all records
+	Metric        string                 `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`                                                                           // Metric the rule checks, e.g. "p99_ms"
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`                                                                                   // Comparison, e.g. ">"
+	Threshold     float64                `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`                                                                   // Value the metric is compared against
+	Value         float64                `protobuf:"fixed64,6,opt,name=value,proto3" json:"value,omitempty"`                                                                           // Metric value at the last evaluation
+	Window        string                 `protobuf:"bytes,7,opt,name=window,proto3" json:"window,omitempty"`                                                                           // Rolling window evaluated, empty for all-time
+	State         AlertState             `protobuf:"varint,8,opt,name=state,proto3,enum=stats.AlertState" json:"state,omitempty"`
+	ActiveSinceMs int64                  `protobuf:"varint,9,opt,name=active_since_ms,json=activeSinceMs,proto3" json:"active_since_ms,omitempty"` // When the condition started to hold, Unix milliseconds
+	FiredAtMs     int64                  `protobuf:"varint,10,opt,name=fired_at_ms,json=firedAtMs,proto3" json:"fired_at_ms,omitempty"`            // When the alert started firing, zero while pending
+	Description   string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`                            // Free text from the rule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_stats_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[16]
 	if x != nil {
-		return x.FibonacciStats
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Alert) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Alert) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
 	}
 	return nil
 }
 
-// Nested message for per-number stats
-type FibonacciStat struct {
+func (x *Alert) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *Alert) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Alert) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Alert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *Alert) GetState() AlertState {
+	if x != nil {
+		return x.State
+	}
+	return AlertState_ALERT_STATE_PENDING
+}
+
+func (x *Alert) GetActiveSinceMs() int64 {
+	if x != nil {
+		return x.ActiveSinceMs
+	}
+	return 0
+}
+
+func (x *Alert) GetFiredAtMs() int64 {
+	if x != nil {
+		return x.FiredAtMs
+	}
+	return 0
+}
+
+func (x *Alert) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// GetAlertsResponse lists active alerts, firing first, then by rule and labels.
+type GetAlertsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                                 // Fibonacci number requested
-	RequestCount  int32                  `protobuf:"varint,2,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`       // How many times it was requested
-	AverageTimeMs float64                `protobuf:"fixed64,3,opt,name=average_time_ms,json=averageTimeMs,proto3" json:"average_time_ms,omitempty"` // Average computation time in milliseconds
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	Rules         int32                  `protobuf:"varint,2,opt,name=rules,proto3" json:"rules,omitempty"`                                        // Rules configured
+	EvaluatedAtMs int64                  `protobuf:"varint,3,opt,name=evaluated_at_ms,json=evaluatedAtMs,proto3" json:"evaluated_at_ms,omitempty"` // Last evaluation, Unix milliseconds; zero if none yet
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FibonacciStat) Reset() {
-	*x = FibonacciStat{}
-	mi := &file_stats_proto_msgTypes[1]
+func (x *GetAlertsResponse) Reset() {
+	*x = GetAlertsResponse{}
+	mi := &file_stats_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FibonacciStat) String() string {
+func (x *GetAlertsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FibonacciStat) ProtoMessage() {}
+func (*GetAlertsResponse) ProtoMessage() {}
 
-func (x *FibonacciStat) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[1]
+func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -109,55 +1621,124 @@ func (x *FibonacciStat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FibonacciStat.ProtoReflect.Descriptor instead.
-func (*FibonacciStat) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetAlertsResponse.ProtoReflect.Descriptor instead.
+func (*GetAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *FibonacciStat) GetN() int32 {
+func (x *GetAlertsResponse) GetAlerts() []*Alert {
 	if x != nil {
-		return x.N
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *GetAlertsResponse) GetRules() int32 {
+	if x != nil {
+		return x.Rules
 	}
 	return 0
 }
 
-func (x *FibonacciStat) GetRequestCount() int32 {
+func (x *GetAlertsResponse) GetEvaluatedAtMs() int64 {
 	if x != nil {
-		return x.RequestCount
+		return x.EvaluatedAtMs
 	}
 	return 0
 }
 
-func (x *FibonacciStat) GetAverageTimeMs() float64 {
+// GetSlowLogRequest selects which slow-log entries GetSlowLog returns.
+type GetSlowLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                         // Return at most this many entries, 0 for all held
+	MinDurationUs float64                `protobuf:"fixed64,2,opt,name=min_duration_us,json=minDurationUs,proto3" json:"min_duration_us,omitempty"` // Only entries at least this slow; the recording threshold still applies
+	N             []int32                `protobuf:"varint,3,rep,packed,name=n,proto3" json:"n,omitempty"`                                          // Only these numbers; empty for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSlowLogRequest) Reset() {
+	*x = GetSlowLogRequest{}
+	mi := &file_stats_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSlowLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSlowLogRequest) ProtoMessage() {}
+
+func (x *GetSlowLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[18]
 	if x != nil {
-		return x.AverageTimeMs
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSlowLogRequest.ProtoReflect.Descriptor instead.
+func (*GetSlowLogRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSlowLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-type RecordRequest struct {
+func (x *GetSlowLogRequest) GetMinDurationUs() float64 {
+	if x != nil {
+		return x.MinDurationUs
+	}
+	return 0
+}
+
+func (x *GetSlowLogRequest) GetN() []int32 {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+// SlowRequest is one computation in the slow log.
+type SlowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"` // input number
-	Duration      int64                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                                               // Fibonacci number requested
+	DurationUs    float64                `protobuf:"fixed64,2,opt,name=duration_us,json=durationUs,proto3" json:"duration_us,omitempty"`                          // Computation time in microseconds
+	InstanceId    string                 `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`                            // Fibonacci instance that served it
+	TimestampMs   int64                  `protobuf:"varint,4,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`                        // When it was served, Unix milliseconds
+	CacheStatus   CacheStatus            `protobuf:"varint,5,opt,name=cache_status,json=cacheStatus,proto3,enum=stats.CacheStatus" json:"cache_status,omitempty"` // Cache hit, miss or bypass
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                               // Gateway request ID
+	Algorithm     string                 `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                                                // How the result was produced
+	StatusCode    int32                  `protobuf:"varint,8,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`                           // gRPC status code returned to the caller
+	ClientId      string                 `protobuf:"bytes,9,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                  // Client that made the request
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordRequest) Reset() {
-	*x = RecordRequest{}
-	mi := &file_stats_proto_msgTypes[2]
+func (x *SlowRequest) Reset() {
+	*x = SlowRequest{}
+	mi := &file_stats_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordRequest) String() string {
+func (x *SlowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordRequest) ProtoMessage() {}
+func (*SlowRequest) ProtoMessage() {}
 
-func (x *RecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[2]
+func (x *SlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,47 +1749,100 @@ func (x *RecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordRequest.ProtoReflect.Descriptor instead.
-func (*RecordRequest) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SlowRequest.ProtoReflect.Descriptor instead.
+func (*SlowRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *RecordRequest) GetN() int32 {
+func (x *SlowRequest) GetN() int32 {
 	if x != nil {
 		return x.N
 	}
 	return 0
 }
 
-func (x *RecordRequest) GetDuration() int64 {
+func (x *SlowRequest) GetDurationUs() float64 {
 	if x != nil {
-		return x.Duration
+		return x.DurationUs
 	}
 	return 0
 }
 
-type RecordResponse struct {
+func (x *SlowRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *SlowRequest) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *SlowRequest) GetCacheStatus() CacheStatus {
+	if x != nil {
+		return x.CacheStatus
+	}
+	return CacheStatus_CACHE_STATUS_UNKNOWN
+}
+
+func (x *SlowRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SlowRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *SlowRequest) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *SlowRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+// GetSlowLogResponse lists slow computations, newest first.
+type GetSlowLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // record result
+	Entries       []*SlowRequest         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	ThresholdUs   float64                `protobuf:"fixed64,2,opt,name=threshold_us,json=thresholdUs,proto3" json:"threshold_us,omitempty"` // Computations at least this slow are logged (STATS_SLOWLOG_THRESHOLD)
+	Capacity      int32                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`                           // Entries held before the oldest are overwritten (STATS_SLOWLOG_SIZE)
+	TotalSlow     int64                  `protobuf:"varint,4,opt,name=total_slow,json=totalSlow,proto3" json:"total_slow,omitempty"`        // Slow computations logged since the Stats service started, including overwritten ones
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordResponse) Reset() {
-	*x = RecordResponse{}
-	mi := &file_stats_proto_msgTypes[3]
+func (x *GetSlowLogResponse) Reset() {
+	*x = GetSlowLogResponse{}
+	mi := &file_stats_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordResponse) String() string {
+func (x *GetSlowLogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordResponse) ProtoMessage() {}
+func (*GetSlowLogResponse) ProtoMessage() {}
 
-func (x *RecordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[3]
+func (x *GetSlowLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,38 +1853,245 @@ func (x *RecordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordResponse.ProtoReflect.Descriptor instead.
-func (*RecordResponse) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetSlowLogResponse.ProtoReflect.Descriptor instead.
+func (*GetSlowLogResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *RecordResponse) GetSuccess() bool {
+func (x *GetSlowLogResponse) GetEntries() []*SlowRequest {
 	if x != nil {
-		return x.Success
+		return x.Entries
 	}
-	return false
+	return nil
+}
+
+func (x *GetSlowLogResponse) GetThresholdUs() float64 {
+	if x != nil {
+		return x.ThresholdUs
+	}
+	return 0
+}
+
+func (x *GetSlowLogResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *GetSlowLogResponse) GetTotalSlow() int64 {
+	if x != nil {
+		return x.TotalSlow
+	}
+	return 0
 }
 
 var File_stats_proto protoreflect.FileDescriptor
 
 const file_stats_proto_rawDesc = "" +
 	"\n" +
-	"\vstats.proto\x12\x05stats\x1a\x1bgoogle/protobuf/empty.proto\"u\n" +
+	"\vstats.proto\x12\x05stats\"\xb9\x03\n" +
+	"\fStatsRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x03(\tR\agroupBy\x12:\n" +
+	"\afilters\x18\x03 \x03(\v2 .stats.StatsRequest.FiltersEntryR\afilters\x12\x18\n" +
+	"\x05min_n\x18\x04 \x01(\x05H\x00R\x04minN\x88\x01\x01\x12\x18\n" +
+	"\x05max_n\x18\x05 \x01(\x05H\x01R\x04maxN\x88\x01\x01\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descending\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tpage_size\x18\t \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\x12+\n" +
+	"\x11include_instances\x18\v \x01(\bR\x10includeInstances\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_min_nB\b\n" +
+	"\x06_max_n\"\xc8\x03\n" +
 	"\rStatsResponse\x12%\n" +
 	"\x0etotal_requests\x18\x01 \x01(\x05R\rtotalRequests\x12=\n" +
-	"\x0ffibonacci_stats\x18\x02 \x03(\v2\x14.stats.FibonacciStatR\x0efibonacciStats\"j\n" +
+	"\x0ffibonacci_stats\x18\x02 \x03(\v2\x14.stats.FibonacciStatR\x0efibonacciStats\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\tR\x06window\x12\x10\n" +
+	"\x03qps\x18\x04 \x01(\x01R\x03qps\x12!\n" +
+	"\ftotal_errors\x18\x05 \x01(\x05R\vtotalErrors\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x06 \x01(\x01R\terrorRate\x12&\n" +
+	"\x0fnext_page_token\x18\a \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\b \x01(\x05R\ttotalRows\x12 \n" +
+	"\vapproximate\x18\t \x01(\bR\vapproximate\x12-\n" +
+	"\x12duplicates_dropped\x18\n" +
+	" \x01(\x03R\x11duplicatesDropped\x12\x1a\n" +
+	"\breplicas\x18\v \x01(\x05R\breplicas\x121\n" +
+	"\tinstances\x18\f \x03(\v2\x13.stats.InstanceStatR\tinstances\"\x8e\x01\n" +
+	"\fInstanceStat\x12\x1a\n" +
+	"\binstance\x18\x01 \x01(\tR\binstance\x12*\n" +
+	"\x05stats\x18\x02 \x01(\v2\x14.stats.FibonacciStatR\x05stats\x12 \n" +
+	"\flast_seen_ms\x18\x03 \x01(\x03R\n" +
+	"lastSeenMs\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\"\xba\x04\n" +
 	"\rFibonacciStat\x12\f\n" +
 	"\x01n\x18\x01 \x01(\x05R\x01n\x12#\n" +
 	"\rrequest_count\x18\x02 \x01(\x05R\frequestCount\x12&\n" +
-	"\x0faverage_time_ms\x18\x03 \x01(\x01R\raverageTimeMs\"9\n" +
+	"\x0faverage_time_ms\x18\x03 \x01(\x01R\raverageTimeMs\x12\x15\n" +
+	"\x06min_us\x18\x04 \x01(\x01R\x05minUs\x12\x15\n" +
+	"\x06max_us\x18\x05 \x01(\x01R\x05maxUs\x12\x15\n" +
+	"\x06p50_us\x18\x06 \x01(\x01R\x05p50Us\x12\x15\n" +
+	"\x06p90_us\x18\a \x01(\x01R\x05p90Us\x12\x15\n" +
+	"\x06p99_us\x18\b \x01(\x01R\x05p99Us\x12\x17\n" +
+	"\ap999_us\x18\t \x01(\x01R\x06p999Us\x12\x10\n" +
+	"\x03qps\x18\n" +
+	" \x01(\x01R\x03qps\x128\n" +
+	"\x06labels\x18\v \x03(\v2 .stats.FibonacciStat.LabelsEntryR\x06labels\x120\n" +
+	"\x14average_result_bytes\x18\f \x01(\x01R\x12averageResultBytes\x12\x1f\n" +
+	"\verror_count\x18\r \x01(\x05R\n" +
+	"errorCount\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x0e \x01(\x01R\terrorRate\x12'\n" +
+	"\x0festimated_count\x18\x0f \x01(\x05R\x0eestimatedCount\x12 \n" +
+	"\vapproximate\x18\x10 \x01(\bR\vapproximate\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x03\n" +
 	"\rRecordRequest\x12\f\n" +
 	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x1a\n" +
-	"\bduration\x18\x02 \x01(\x03R\bduration\"*\n" +
+	"\bduration\x18\x02 \x01(\x03R\bduration\x125\n" +
+	"\fcache_status\x18\x03 \x01(\x0e2\x12.stats.CacheStatusR\vcacheStatus\x12\x1c\n" +
+	"\talgorithm\x18\x04 \x01(\tR\talgorithm\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12\x1f\n" +
+	"\vinstance_id\x18\x06 \x01(\tR\n" +
+	"instanceId\x12\x1b\n" +
+	"\tclient_id\x18\a \x01(\tR\bclientId\x12!\n" +
+	"\ftimestamp_ms\x18\b \x01(\x03R\vtimestampMs\x12\x1f\n" +
+	"\vresult_size\x18\t \x01(\x05R\n" +
+	"resultSize\x12\x19\n" +
+	"\bevent_id\x18\n" +
+	" \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\v \x01(\tR\trequestId\x12#\n" +
+	"\rsample_weight\x18\f \x01(\x05R\fsampleWeight\"D\n" +
+	"\x12RecordBatchRequest\x12.\n" +
+	"\arecords\x18\x01 \x03(\v2\x14.stats.RecordRequestR\arecords\"i\n" +
+	"\x13RecordBatchResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\"H\n" +
 	"\x0eRecordResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2z\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tduplicate\x18\x02 \x01(\bR\tduplicate\"h\n" +
+	"\x11QueryStatsRequest\x12\x17\n" +
+	"\afrom_ms\x18\x01 \x01(\x03R\x06fromMs\x12\x13\n" +
+	"\x05to_ms\x18\x02 \x01(\x03R\x04toMs\x12\x17\n" +
+	"\astep_ms\x18\x03 \x01(\x03R\x06stepMs\x12\f\n" +
+	"\x01n\x18\x04 \x03(\x05R\x01n\"X\n" +
+	"\x12QueryStatsResponse\x12\x17\n" +
+	"\astep_ms\x18\x01 \x01(\x03R\x06stepMs\x12)\n" +
+	"\x06series\x18\x02 \x03(\v2\x11.stats.TimeSeriesR\x06series\"d\n" +
+	"\n" +
+	"TimeSeries\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x1c\n" +
+	"\taggregate\x18\x02 \x01(\bR\taggregate\x12*\n" +
+	"\x06points\x18\x03 \x03(\v2\x12.stats.SeriesPointR\x06points\"\xd3\x01\n" +
+	"\vSeriesPoint\x12!\n" +
+	"\ftimestamp_ms\x18\x01 \x01(\x03R\vtimestampMs\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x10\n" +
+	"\x03qps\x18\x03 \x01(\x01R\x03qps\x12\x1d\n" +
+	"\n" +
+	"average_us\x18\x04 \x01(\x01R\taverageUs\x12\x15\n" +
+	"\x06p50_us\x18\x05 \x01(\x01R\x05p50Us\x12\x15\n" +
+	"\x06p90_us\x18\x06 \x01(\x01R\x05p90Us\x12\x15\n" +
+	"\x06p99_us\x18\a \x01(\x01R\x05p99Us\x12\x15\n" +
+	"\x06max_us\x18\b \x01(\x01R\x05maxUs\"Z\n" +
+	"\x11WatchStatsRequest\x12\x1f\n" +
+	"\vinterval_ms\x18\x01 \x01(\x03R\n" +
+	"intervalMs\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\f\n" +
+	"\x01n\x18\x03 \x03(\x05R\x01n\"y\n" +
+	"\rRecordedEvent\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x1f\n" +
+	"\vduration_us\x18\x02 \x01(\x01R\n" +
+	"durationUs\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x03R\x06weight\"\x9a\x01\n" +
+	"\x12WatchStatsResponse\x122\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x14.stats.StatsResponseH\x00R\bsnapshot\x12,\n" +
+	"\x05event\x18\x02 \x01(\v2\x14.stats.RecordedEventH\x00R\x05event\x12\x18\n" +
+	"\adropped\x18\x03 \x01(\x03R\adroppedB\b\n" +
+	"\x06update\";\n" +
+	"\x10GetAlertsRequest\x12'\n" +
+	"\x0finclude_pending\x18\x01 \x01(\bR\x0eincludePending\"\x8f\x03\n" +
+	"\x05Alert\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x120\n" +
+	"\x06labels\x18\x02 \x03(\v2\x18.stats.Alert.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06metric\x18\x03 \x01(\tR\x06metric\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x1c\n" +
+	"\tthreshold\x18\x05 \x01(\x01R\tthreshold\x12\x14\n" +
+	"\x05value\x18\x06 \x01(\x01R\x05value\x12\x16\n" +
+	"\x06window\x18\a \x01(\tR\x06window\x12'\n" +
+	"\x05state\x18\b \x01(\x0e2\x11.stats.AlertStateR\x05state\x12&\n" +
+	"\x0factive_since_ms\x18\t \x01(\x03R\ractiveSinceMs\x12\x1e\n" +
+	"\vfired_at_ms\x18\n" +
+	" \x01(\x03R\tfiredAtMs\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"w\n" +
+	"\x11GetAlertsResponse\x12$\n" +
+	"\x06alerts\x18\x01 \x03(\v2\f.stats.AlertR\x06alerts\x12\x14\n" +
+	"\x05rules\x18\x02 \x01(\x05R\x05rules\x12&\n" +
+	"\x0fevaluated_at_ms\x18\x03 \x01(\x03R\revaluatedAtMs\"_\n" +
+	"\x11GetSlowLogRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12&\n" +
+	"\x0fmin_duration_us\x18\x02 \x01(\x01R\rminDurationUs\x12\f\n" +
+	"\x01n\x18\x03 \x03(\x05R\x01n\"\xb2\x02\n" +
+	"\vSlowRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x1f\n" +
+	"\vduration_us\x18\x02 \x01(\x01R\n" +
+	"durationUs\x12\x1f\n" +
+	"\vinstance_id\x18\x03 \x01(\tR\n" +
+	"instanceId\x12!\n" +
+	"\ftimestamp_ms\x18\x04 \x01(\x03R\vtimestampMs\x125\n" +
+	"\fcache_status\x18\x05 \x01(\x0e2\x12.stats.CacheStatusR\vcacheStatus\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\x12\x1c\n" +
+	"\talgorithm\x18\a \x01(\tR\talgorithm\x12\x1f\n" +
+	"\vstatus_code\x18\b \x01(\x05R\n" +
+	"statusCode\x12\x1b\n" +
+	"\tclient_id\x18\t \x01(\tR\bclientId\"\xa0\x01\n" +
+	"\x12GetSlowLogResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.stats.SlowRequestR\aentries\x12!\n" +
+	"\fthreshold_us\x18\x02 \x01(\x01R\vthresholdUs\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x05R\bcapacity\x12\x1d\n" +
+	"\n" +
+	"total_slow\x18\x04 \x01(\x03R\ttotalSlow*m\n" +
+	"\vCacheStatus\x12\x18\n" +
+	"\x14CACHE_STATUS_UNKNOWN\x10\x00\x12\x14\n" +
+	"\x10CACHE_STATUS_HIT\x10\x01\x12\x15\n" +
+	"\x11CACHE_STATUS_MISS\x10\x02\x12\x17\n" +
+	"\x13CACHE_STATUS_BYPASS\x10\x03*=\n" +
+	"\n" +
+	"AlertState\x12\x17\n" +
+	"\x13ALERT_STATE_PENDING\x10\x00\x12\x16\n" +
+	"\x12ALERT_STATE_FIRING\x10\x012\x8c\x04\n" +
 	"\x05Stats\x127\n" +
-	"\bRecordNo\x12\x14.stats.RecordRequest\x1a\x15.stats.RecordResponse\x128\n" +
-	"\bGetStats\x12\x16.google.protobuf.Empty\x1a\x14.stats.StatsResponseB\x1eZ\x1cyourrepo/proto/stats;statspbb\x06proto3"
+	"\bRecordNo\x12\x14.stats.RecordRequest\x1a\x15.stats.RecordResponse\x12D\n" +
+	"\vRecordBatch\x12\x19.stats.RecordBatchRequest\x1a\x1a.stats.RecordBatchResponse\x12B\n" +
+	"\fRecordStream\x12\x14.stats.RecordRequest\x1a\x1a.stats.RecordBatchResponse(\x01\x125\n" +
+	"\bGetStats\x12\x13.stats.StatsRequest\x1a\x14.stats.StatsResponse\x12A\n" +
+	"\n" +
+	"QueryStats\x12\x18.stats.QueryStatsRequest\x1a\x19.stats.QueryStatsResponse\x12C\n" +
+	"\n" +
+	"WatchStats\x12\x18.stats.WatchStatsRequest\x1a\x19.stats.WatchStatsResponse0\x01\x12>\n" +
+	"\tGetAlerts\x12\x17.stats.GetAlertsRequest\x1a\x18.stats.GetAlertsResponse\x12A\n" +
+	"\n" +
+	"GetSlowLog\x12\x18.stats.GetSlowLogRequest\x1a\x19.stats.GetSlowLogResponseB$Z\"fibonacci-grpc/proto/stats;statspbb\x06proto3"
 
 var (
 	file_stats_proto_rawDescOnce sync.Once
@@ -264,25 +2105,74 @@ func file_stats_proto_rawDescGZIP() []byte {
 	return file_stats_proto_rawDescData
 }
 
-var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_stats_proto_goTypes = []any{
-	(*StatsResponse)(nil),  // 0: stats.StatsResponse
-	(*FibonacciStat)(nil),  // 1: stats.FibonacciStat
-	(*RecordRequest)(nil),  // 2: stats.RecordRequest
-	(*RecordResponse)(nil), // 3: stats.RecordResponse
-	(*emptypb.Empty)(nil),  // 4: google.protobuf.Empty
+	(CacheStatus)(0),            // 0: stats.CacheStatus
+	(AlertState)(0),             // 1: stats.AlertState
+	(*StatsRequest)(nil),        // 2: stats.StatsRequest
+	(*StatsResponse)(nil),       // 3: stats.StatsResponse
+	(*InstanceStat)(nil),        // 4: stats.InstanceStat
+	(*FibonacciStat)(nil),       // 5: stats.FibonacciStat
+	(*RecordRequest)(nil),       // 6: stats.RecordRequest
+	(*RecordBatchRequest)(nil),  // 7: stats.RecordBatchRequest
+	(*RecordBatchResponse)(nil), // 8: stats.RecordBatchResponse
+	(*RecordResponse)(nil),      // 9: stats.RecordResponse
+	(*QueryStatsRequest)(nil),   // 10: stats.QueryStatsRequest
+	(*QueryStatsResponse)(nil),  // 11: stats.QueryStatsResponse
+	(*TimeSeries)(nil),          // 12: stats.TimeSeries
+	(*SeriesPoint)(nil),         // 13: stats.SeriesPoint
+	(*WatchStatsRequest)(nil),   // 14: stats.WatchStatsRequest
+	(*RecordedEvent)(nil),       // 15: stats.RecordedEvent
+	(*WatchStatsResponse)(nil),  // 16: stats.WatchStatsResponse
+	(*GetAlertsRequest)(nil),    // 17: stats.GetAlertsRequest
+	(*Alert)(nil),               // 18: stats.Alert
+	(*GetAlertsResponse)(nil),   // 19: stats.GetAlertsResponse
+	(*GetSlowLogRequest)(nil),   // 20: stats.GetSlowLogRequest
+	(*SlowRequest)(nil),         // 21: stats.SlowRequest
+	(*GetSlowLogResponse)(nil),  // 22: stats.GetSlowLogResponse
+	nil,                         // 23: stats.StatsRequest.FiltersEntry
+	nil,                         // 24: stats.FibonacciStat.LabelsEntry
+	nil,                         // 25: stats.Alert.LabelsEntry
 }
 var file_stats_proto_depIdxs = []int32{
-	1, // 0: stats.StatsResponse.fibonacci_stats:type_name -> stats.FibonacciStat
-	2, // 1: stats.Stats.RecordNo:input_type -> stats.RecordRequest
-	4, // 2: stats.Stats.GetStats:input_type -> google.protobuf.Empty
-	3, // 3: stats.Stats.RecordNo:output_type -> stats.RecordResponse
-	0, // 4: stats.Stats.GetStats:output_type -> stats.StatsResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	23, // 0: stats.StatsRequest.filters:type_name -> stats.StatsRequest.FiltersEntry
+	5,  // 1: stats.StatsResponse.fibonacci_stats:type_name -> stats.FibonacciStat
+	4,  // 2: stats.StatsResponse.instances:type_name -> stats.InstanceStat
+	5,  // 3: stats.InstanceStat.stats:type_name -> stats.FibonacciStat
+	24, // 4: stats.FibonacciStat.labels:type_name -> stats.FibonacciStat.LabelsEntry
+	0,  // 5: stats.RecordRequest.cache_status:type_name -> stats.CacheStatus
+	6,  // 6: stats.RecordBatchRequest.records:type_name -> stats.RecordRequest
+	12, // 7: stats.QueryStatsResponse.series:type_name -> stats.TimeSeries
+	13, // 8: stats.TimeSeries.points:type_name -> stats.SeriesPoint
+	3,  // 9: stats.WatchStatsResponse.snapshot:type_name -> stats.StatsResponse
+	15, // 10: stats.WatchStatsResponse.event:type_name -> stats.RecordedEvent
+	25, // 11: stats.Alert.labels:type_name -> stats.Alert.LabelsEntry
+	1,  // 12: stats.Alert.state:type_name -> stats.AlertState
+	18, // 13: stats.GetAlertsResponse.alerts:type_name -> stats.Alert
+	0,  // 14: stats.SlowRequest.cache_status:type_name -> stats.CacheStatus
+	21, // 15: stats.GetSlowLogResponse.entries:type_name -> stats.SlowRequest
+	6,  // 16: stats.Stats.RecordNo:input_type -> stats.RecordRequest
+	7,  // 17: stats.Stats.RecordBatch:input_type -> stats.RecordBatchRequest
+	6,  // 18: stats.Stats.RecordStream:input_type -> stats.RecordRequest
+	2,  // 19: stats.Stats.GetStats:input_type -> stats.StatsRequest
+	10, // 20: stats.Stats.QueryStats:input_type -> stats.QueryStatsRequest
+	14, // 21: stats.Stats.WatchStats:input_type -> stats.WatchStatsRequest
+	17, // 22: stats.Stats.GetAlerts:input_type -> stats.GetAlertsRequest
+	20, // 23: stats.Stats.GetSlowLog:input_type -> stats.GetSlowLogRequest
+	9,  // 24: stats.Stats.RecordNo:output_type -> stats.RecordResponse
+	8,  // 25: stats.Stats.RecordBatch:output_type -> stats.RecordBatchResponse
+	8,  // 26: stats.Stats.RecordStream:output_type -> stats.RecordBatchResponse
+	3,  // 27: stats.Stats.GetStats:output_type -> stats.StatsResponse
+	11, // 28: stats.Stats.QueryStats:output_type -> stats.QueryStatsResponse
+	16, // 29: stats.Stats.WatchStats:output_type -> stats.WatchStatsResponse
+	19, // 30: stats.Stats.GetAlerts:output_type -> stats.GetAlertsResponse
+	22, // 31: stats.Stats.GetSlowLog:output_type -> stats.GetSlowLogResponse
+	24, // [24:32] is the sub-list for method output_type
+	16, // [16:24] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_stats_proto_init() }
@@ -290,18 +2180,24 @@ func file_stats_proto_init() {
 	if File_stats_proto != nil {
 		return
 	}
+	file_stats_proto_msgTypes[0].OneofWrappers = []any{}
+	file_stats_proto_msgTypes[14].OneofWrappers = []any{
+		(*WatchStatsResponse_Snapshot)(nil),
+		(*WatchStatsResponse_Event)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_proto_rawDesc), len(file_stats_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      2,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_stats_proto_goTypes,
 		DependencyIndexes: file_stats_proto_depIdxs,
+		EnumInfos:         file_stats_proto_enumTypes,
 		MessageInfos:      file_stats_proto_msgTypes,
 	}.Build()
 	File_stats_proto = out.File
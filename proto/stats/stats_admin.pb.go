@@ -0,0 +1,1151 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.27.2
+// source: stats_admin.proto
+
+package statspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StatsFormat is the encoding of exported statistics.
+type StatsFormat int32
+
+const (
+	StatsFormat_STATS_FORMAT_NDJSON StatsFormat = 0 // One protobuf-JSON StatsRecord per line
+	StatsFormat_STATS_FORMAT_CSV    StatsFormat = 1 // Header row plus one row per StatsRecord
+	StatsFormat_STATS_FORMAT_JSON   StatsFormat = 2 // A single protobuf-JSON StatsDump
+)
+
+// Enum value maps for StatsFormat.
+var (
+	StatsFormat_name = map[int32]string{
+		0: "STATS_FORMAT_NDJSON",
+		1: "STATS_FORMAT_CSV",
+		2: "STATS_FORMAT_JSON",
+	}
+	StatsFormat_value = map[string]int32{
+		"STATS_FORMAT_NDJSON": 0,
+		"STATS_FORMAT_CSV":    1,
+		"STATS_FORMAT_JSON":   2,
+	}
+)
+
+func (x StatsFormat) Enum() *StatsFormat {
+	p := new(StatsFormat)
+	*p = x
+	return p
+}
+
+func (x StatsFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (StatsFormat) Type() protoreflect.EnumType {
+	return &file_stats_admin_proto_enumTypes[0]
+}
+
+func (x StatsFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsFormat.Descriptor instead.
+func (StatsFormat) EnumDescriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// ImportMode chooses what happens to the existing statistics on import.
+type ImportMode int32
+
+const (
+	ImportMode_IMPORT_MODE_MERGE   ImportMode = 0 // Add the imported aggregates to the existing ones
+	ImportMode_IMPORT_MODE_REPLACE ImportMode = 1 // Delete every existing aggregate first
+)
+
+// Enum value maps for ImportMode.
+var (
+	ImportMode_name = map[int32]string{
+		0: "IMPORT_MODE_MERGE",
+		1: "IMPORT_MODE_REPLACE",
+	}
+	ImportMode_value = map[string]int32{
+		"IMPORT_MODE_MERGE":   0,
+		"IMPORT_MODE_REPLACE": 1,
+	}
+)
+
+func (x ImportMode) Enum() *ImportMode {
+	p := new(ImportMode)
+	*p = x
+	return p
+}
+
+func (x ImportMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_stats_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportMode) Type() protoreflect.EnumType {
+	return &file_stats_admin_proto_enumTypes[1]
+}
+
+func (x ImportMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportMode.Descriptor instead.
+func (ImportMode) EnumDescriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{1}
+}
+
+// StatsRecord is one stored aggregate: a value of 'n' and its dimensions.
+type StatsRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	N              int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Cache          string                 `protobuf:"bytes,2,opt,name=cache,proto3" json:"cache,omitempty"`
+	Algorithm      string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Instance       string                 `protobuf:"bytes,5,opt,name=instance,proto3" json:"instance,omitempty"`
+	Client         string                 `protobuf:"bytes,6,opt,name=client,proto3" json:"client,omitempty"`
+	Count          int64                  `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`                                                                                                                     // Requests
+	Errors         int64                  `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`                                                                                                                   // Failed requests
+	TotalTimeNs    int64                  `protobuf:"varint,9,opt,name=total_time_ns,json=totalTimeNs,proto3" json:"total_time_ns,omitempty"`                                                                                    // Sum of computation times
+	MinNs          int64                  `protobuf:"varint,10,opt,name=min_ns,json=minNs,proto3" json:"min_ns,omitempty"`                                                                                                       // Fastest computation
+	MaxNs          int64                  `protobuf:"varint,11,opt,name=max_ns,json=maxNs,proto3" json:"max_ns,omitempty"`                                                                                                       // Slowest computation
+	ResultBytes    int64                  `protobuf:"varint,12,opt,name=result_bytes,json=resultBytes,proto3" json:"result_bytes,omitempty"`                                                                                     // Sum of result sizes
+	LatencyBuckets map[int32]int64        `protobuf:"bytes,13,rep,name=latency_buckets,json=latencyBuckets,proto3" json:"latency_buckets,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Latency histogram bucket index -> count
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StatsRecord) Reset() {
+	*x = StatsRecord{}
+	mi := &file_stats_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRecord) ProtoMessage() {}
+
+func (x *StatsRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRecord.ProtoReflect.Descriptor instead.
+func (*StatsRecord) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatsRecord) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetCache() string {
+	if x != nil {
+		return x.Cache
+	}
+	return ""
+}
+
+func (x *StatsRecord) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *StatsRecord) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatsRecord) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *StatsRecord) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *StatsRecord) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetTotalTimeNs() int64 {
+	if x != nil {
+		return x.TotalTimeNs
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetMinNs() int64 {
+	if x != nil {
+		return x.MinNs
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetMaxNs() int64 {
+	if x != nil {
+		return x.MaxNs
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetResultBytes() int64 {
+	if x != nil {
+		return x.ResultBytes
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetLatencyBuckets() map[int32]int64 {
+	if x != nil {
+		return x.LatencyBuckets
+	}
+	return nil
+}
+
+// StatsDump is the document written by STATS_FORMAT_JSON.
+type StatsDump struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportedAtMs  int64                  `protobuf:"varint,1,opt,name=exported_at_ms,json=exportedAtMs,proto3" json:"exported_at_ms,omitempty"`
+	Records       []*StatsRecord         `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsDump) Reset() {
+	*x = StatsDump{}
+	mi := &file_stats_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsDump) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsDump) ProtoMessage() {}
+
+func (x *StatsDump) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsDump.ProtoReflect.Descriptor instead.
+func (*StatsDump) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatsDump) GetExportedAtMs() int64 {
+	if x != nil {
+		return x.ExportedAtMs
+	}
+	return 0
+}
+
+func (x *StatsDump) GetRecords() []*StatsRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// ExportStatsRequest selects the export format.
+type ExportStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        StatsFormat            `protobuf:"varint,1,opt,name=format,proto3,enum=stats.StatsFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStatsRequest) Reset() {
+	*x = ExportStatsRequest{}
+	mi := &file_stats_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStatsRequest) ProtoMessage() {}
+
+func (x *ExportStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStatsRequest.ProtoReflect.Descriptor instead.
+func (*ExportStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportStatsRequest) GetFormat() StatsFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StatsFormat_STATS_FORMAT_NDJSON
+}
+
+// StatsChunk is the next piece of an export.
+type StatsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsChunk) Reset() {
+	*x = StatsChunk{}
+	mi := &file_stats_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsChunk) ProtoMessage() {}
+
+func (x *StatsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsChunk.ProtoReflect.Descriptor instead.
+func (*StatsChunk) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StatsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportStatsRequest carries the next piece of an import. format and mode
+// are read from the first message only.
+type ImportStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        StatsFormat            `protobuf:"varint,1,opt,name=format,proto3,enum=stats.StatsFormat" json:"format,omitempty"`
+	Mode          ImportMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=stats.ImportMode" json:"mode,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStatsRequest) Reset() {
+	*x = ImportStatsRequest{}
+	mi := &file_stats_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStatsRequest) ProtoMessage() {}
+
+func (x *ImportStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStatsRequest.ProtoReflect.Descriptor instead.
+func (*ImportStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportStatsRequest) GetFormat() StatsFormat {
+	if x != nil {
+		return x.Format
+	}
+	return StatsFormat_STATS_FORMAT_NDJSON
+}
+
+func (x *ImportStatsRequest) GetMode() ImportMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ImportMode_IMPORT_MODE_MERGE
+}
+
+func (x *ImportStatsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportStatsResponse reports what was imported.
+type ImportStatsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Imported         int32                  `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`                                         // Aggregates imported
+	ImportedRequests int32                  `protobuf:"varint,2,opt,name=imported_requests,json=importedRequests,proto3" json:"imported_requests,omitempty"` // Requests those aggregates counted
+	Deleted          int32                  `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`                                           // Existing aggregates deleted by IMPORT_MODE_REPLACE
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportStatsResponse) Reset() {
+	*x = ImportStatsResponse{}
+	mi := &file_stats_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStatsResponse) ProtoMessage() {}
+
+func (x *ImportStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStatsResponse.ProtoReflect.Descriptor instead.
+func (*ImportStatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportStatsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportStatsResponse) GetImportedRequests() int32 {
+	if x != nil {
+		return x.ImportedRequests
+	}
+	return 0
+}
+
+func (x *ImportStatsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// DeleteStatsRequest selects the values of 'n' to delete.
+type DeleteStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             []int32                `protobuf:"varint,1,rep,packed,name=n,proto3" json:"n,omitempty"` // Values of 'n' whose statistics are deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStatsRequest) Reset() {
+	*x = DeleteStatsRequest{}
+	mi := &file_stats_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStatsRequest) ProtoMessage() {}
+
+func (x *DeleteStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStatsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteStatsRequest) GetN() []int32 {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+// DeleteStatsResponse reports how much was deleted.
+type DeleteStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Deleted         int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`                                        // Aggregates removed from the store (one per 'n' and dimensions)
+	DeletedRequests int32                  `protobuf:"varint,2,opt,name=deleted_requests,json=deletedRequests,proto3" json:"deleted_requests,omitempty"` // Requests those aggregates counted
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteStatsResponse) Reset() {
+	*x = DeleteStatsResponse{}
+	mi := &file_stats_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStatsResponse) ProtoMessage() {}
+
+func (x *DeleteStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStatsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStatsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteStatsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *DeleteStatsResponse) GetDeletedRequests() int32 {
+	if x != nil {
+		return x.DeletedRequests
+	}
+	return 0
+}
+
+// TakeSnapshotRequest names a new snapshot.
+type TakeSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique snapshot name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TakeSnapshotRequest) Reset() {
+	*x = TakeSnapshotRequest{}
+	mi := &file_stats_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TakeSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeSnapshotRequest) ProtoMessage() {}
+
+func (x *TakeSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*TakeSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TakeSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Snapshot describes a saved snapshot.
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                         // Snapshot name
+	TakenAtMs     int64                  `protobuf:"varint,2,opt,name=taken_at_ms,json=takenAtMs,proto3" json:"taken_at_ms,omitempty"`           // When it was taken, Unix milliseconds
+	TotalRequests int32                  `protobuf:"varint,3,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"` // Requests recorded at that time
+	Ns            int32                  `protobuf:"varint,4,opt,name=ns,proto3" json:"ns,omitempty"`                                            // Distinct values of 'n' at that time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_stats_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snapshot) GetTakenAtMs() int64 {
+	if x != nil {
+		return x.TakenAtMs
+	}
+	return 0
+}
+
+func (x *Snapshot) GetTotalRequests() int32 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNs() int32 {
+	if x != nil {
+		return x.Ns
+	}
+	return 0
+}
+
+// ListSnapshotsResponse lists saved snapshots.
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshots     []*Snapshot            `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_stats_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// DiffSnapshotsRequest names the snapshots to compare.
+type DiffSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Earlier snapshot
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Later snapshot, empty for the current statistics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSnapshotsRequest) Reset() {
+	*x = DiffSnapshotsRequest{}
+	mi := &file_stats_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSnapshotsRequest) ProtoMessage() {}
+
+func (x *DiffSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DiffSnapshotsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DiffSnapshotsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// StatDiff is the change in one value of 'n' between two snapshots.
+type StatDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int32                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`                                                 // Fibonacci number requested
+	RequestCount  int32                  `protobuf:"varint,2,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`       // Requests recorded in between
+	ErrorCount    int32                  `protobuf:"varint,3,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`             // Failed requests recorded in between
+	AverageTimeMs float64                `protobuf:"fixed64,4,opt,name=average_time_ms,json=averageTimeMs,proto3" json:"average_time_ms,omitempty"` // Average computation time of those requests
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatDiff) Reset() {
+	*x = StatDiff{}
+	mi := &file_stats_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatDiff) ProtoMessage() {}
+
+func (x *StatDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatDiff.ProtoReflect.Descriptor instead.
+func (*StatDiff) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StatDiff) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *StatDiff) GetRequestCount() int32 {
+	if x != nil {
+		return x.RequestCount
+	}
+	return 0
+}
+
+func (x *StatDiff) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *StatDiff) GetAverageTimeMs() float64 {
+	if x != nil {
+		return x.AverageTimeMs
+	}
+	return 0
+}
+
+// DiffSnapshotsResponse reports what was recorded between two snapshots.
+type DiffSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *Snapshot              `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Snapshot              `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TotalRequests int32                  `protobuf:"varint,3,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"` // Requests recorded in between
+	StatDiffs     []*StatDiff            `protobuf:"bytes,4,rep,name=stat_diffs,json=statDiffs,proto3" json:"stat_diffs,omitempty"`              // Per-n changes, sorted by 'n'; unchanged 'n' are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSnapshotsResponse) Reset() {
+	*x = DiffSnapshotsResponse{}
+	mi := &file_stats_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSnapshotsResponse) ProtoMessage() {}
+
+func (x *DiffSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*DiffSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DiffSnapshotsResponse) GetFrom() *Snapshot {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *DiffSnapshotsResponse) GetTo() *Snapshot {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *DiffSnapshotsResponse) GetTotalRequests() int32 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *DiffSnapshotsResponse) GetStatDiffs() []*StatDiff {
+	if x != nil {
+		return x.StatDiffs
+	}
+	return nil
+}
+
+var File_stats_admin_proto protoreflect.FileDescriptor
+
+const file_stats_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11stats_admin.proto\x12\x05stats\x1a\x1bgoogle/protobuf/empty.proto\"\xd2\x03\n" +
+	"\vStatsRecord\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12\x14\n" +
+	"\x05cache\x18\x02 \x01(\tR\x05cache\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\binstance\x18\x05 \x01(\tR\binstance\x12\x16\n" +
+	"\x06client\x18\x06 \x01(\tR\x06client\x12\x14\n" +
+	"\x05count\x18\a \x01(\x03R\x05count\x12\x16\n" +
+	"\x06errors\x18\b \x01(\x03R\x06errors\x12\"\n" +
+	"\rtotal_time_ns\x18\t \x01(\x03R\vtotalTimeNs\x12\x15\n" +
+	"\x06min_ns\x18\n" +
+	" \x01(\x03R\x05minNs\x12\x15\n" +
+	"\x06max_ns\x18\v \x01(\x03R\x05maxNs\x12!\n" +
+	"\fresult_bytes\x18\f \x01(\x03R\vresultBytes\x12O\n" +
+	"\x0flatency_buckets\x18\r \x03(\v2&.stats.StatsRecord.LatencyBucketsEntryR\x0elatencyBuckets\x1aA\n" +
+	"\x13LatencyBucketsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"_\n" +
+	"\tStatsDump\x12$\n" +
+	"\x0eexported_at_ms\x18\x01 \x01(\x03R\fexportedAtMs\x12,\n" +
+	"\arecords\x18\x02 \x03(\v2\x12.stats.StatsRecordR\arecords\"@\n" +
+	"\x12ExportStatsRequest\x12*\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x12.stats.StatsFormatR\x06format\" \n" +
+	"\n" +
+	"StatsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"{\n" +
+	"\x12ImportStatsRequest\x12*\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x12.stats.StatsFormatR\x06format\x12%\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x11.stats.ImportModeR\x04mode\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"x\n" +
+	"\x13ImportStatsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x05R\bimported\x12+\n" +
+	"\x11imported_requests\x18\x02 \x01(\x05R\x10importedRequests\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\x05R\adeleted\"\"\n" +
+	"\x12DeleteStatsRequest\x12\f\n" +
+	"\x01n\x18\x01 \x03(\x05R\x01n\"Z\n" +
+	"\x13DeleteStatsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\x12)\n" +
+	"\x10deleted_requests\x18\x02 \x01(\x05R\x0fdeletedRequests\")\n" +
+	"\x13TakeSnapshotRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"u\n" +
+	"\bSnapshot\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\vtaken_at_ms\x18\x02 \x01(\x03R\ttakenAtMs\x12%\n" +
+	"\x0etotal_requests\x18\x03 \x01(\x05R\rtotalRequests\x12\x0e\n" +
+	"\x02ns\x18\x04 \x01(\x05R\x02ns\"F\n" +
+	"\x15ListSnapshotsResponse\x12-\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x0f.stats.SnapshotR\tsnapshots\":\n" +
+	"\x14DiffSnapshotsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\x86\x01\n" +
+	"\bStatDiff\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x05R\x01n\x12#\n" +
+	"\rrequest_count\x18\x02 \x01(\x05R\frequestCount\x12\x1f\n" +
+	"\verror_count\x18\x03 \x01(\x05R\n" +
+	"errorCount\x12&\n" +
+	"\x0faverage_time_ms\x18\x04 \x01(\x01R\raverageTimeMs\"\xb4\x01\n" +
+	"\x15DiffSnapshotsResponse\x12#\n" +
+	"\x04from\x18\x01 \x01(\v2\x0f.stats.SnapshotR\x04from\x12\x1f\n" +
+	"\x02to\x18\x02 \x01(\v2\x0f.stats.SnapshotR\x02to\x12%\n" +
+	"\x0etotal_requests\x18\x03 \x01(\x05R\rtotalRequests\x12.\n" +
+	"\n" +
+	"stat_diffs\x18\x04 \x03(\v2\x0f.stats.StatDiffR\tstatDiffs*S\n" +
+	"\vStatsFormat\x12\x17\n" +
+	"\x13STATS_FORMAT_NDJSON\x10\x00\x12\x14\n" +
+	"\x10STATS_FORMAT_CSV\x10\x01\x12\x15\n" +
+	"\x11STATS_FORMAT_JSON\x10\x02*<\n" +
+	"\n" +
+	"ImportMode\x12\x15\n" +
+	"\x11IMPORT_MODE_MERGE\x10\x00\x12\x17\n" +
+	"\x13IMPORT_MODE_REPLACE\x10\x012\xe6\x03\n" +
+	"\n" +
+	"StatsAdmin\x12;\n" +
+	"\x05Reset\x12\x16.google.protobuf.Empty\x1a\x1a.stats.DeleteStatsResponse\x12D\n" +
+	"\vDeleteStats\x12\x19.stats.DeleteStatsRequest\x1a\x1a.stats.DeleteStatsResponse\x12;\n" +
+	"\fTakeSnapshot\x12\x1a.stats.TakeSnapshotRequest\x1a\x0f.stats.Snapshot\x12E\n" +
+	"\rListSnapshots\x12\x16.google.protobuf.Empty\x1a\x1c.stats.ListSnapshotsResponse\x12J\n" +
+	"\rDiffSnapshots\x12\x1b.stats.DiffSnapshotsRequest\x1a\x1c.stats.DiffSnapshotsResponse\x12=\n" +
+	"\vExportStats\x12\x19.stats.ExportStatsRequest\x1a\x11.stats.StatsChunk0\x01\x12F\n" +
+	"\vImportStats\x12\x19.stats.ImportStatsRequest\x1a\x1a.stats.ImportStatsResponse(\x01B$Z\"fibonacci-grpc/proto/stats;statspbb\x06proto3"
+
+var (
+	file_stats_admin_proto_rawDescOnce sync.Once
+	file_stats_admin_proto_rawDescData []byte
+)
+
+func file_stats_admin_proto_rawDescGZIP() []byte {
+	file_stats_admin_proto_rawDescOnce.Do(func() {
+		file_stats_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stats_admin_proto_rawDesc), len(file_stats_admin_proto_rawDesc)))
+	})
+	return file_stats_admin_proto_rawDescData
+}
+
+var file_stats_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_stats_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_stats_admin_proto_goTypes = []any{
+	(StatsFormat)(0),              // 0: stats.StatsFormat
+	(ImportMode)(0),               // 1: stats.ImportMode
+	(*StatsRecord)(nil),           // 2: stats.StatsRecord
+	(*StatsDump)(nil),             // 3: stats.StatsDump
+	(*ExportStatsRequest)(nil),    // 4: stats.ExportStatsRequest
+	(*StatsChunk)(nil),            // 5: stats.StatsChunk
+	(*ImportStatsRequest)(nil),    // 6: stats.ImportStatsRequest
+	(*ImportStatsResponse)(nil),   // 7: stats.ImportStatsResponse
+	(*DeleteStatsRequest)(nil),    // 8: stats.DeleteStatsRequest
+	(*DeleteStatsResponse)(nil),   // 9: stats.DeleteStatsResponse
+	(*TakeSnapshotRequest)(nil),   // 10: stats.TakeSnapshotRequest
+	(*Snapshot)(nil),              // 11: stats.Snapshot
+	(*ListSnapshotsResponse)(nil), // 12: stats.ListSnapshotsResponse
+	(*DiffSnapshotsRequest)(nil),  // 13: stats.DiffSnapshotsRequest
+	(*StatDiff)(nil),              // 14: stats.StatDiff
+	(*DiffSnapshotsResponse)(nil), // 15: stats.DiffSnapshotsResponse
+	nil,                           // 16: stats.StatsRecord.LatencyBucketsEntry
+	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+}
+var file_stats_admin_proto_depIdxs = []int32{
+	16, // 0: stats.StatsRecord.latency_buckets:type_name -> stats.StatsRecord.LatencyBucketsEntry
+	2,  // 1: stats.StatsDump.records:type_name -> stats.StatsRecord
+	0,  // 2: stats.ExportStatsRequest.format:type_name -> stats.StatsFormat
+	0,  // 3: stats.ImportStatsRequest.format:type_name -> stats.StatsFormat
+	1,  // 4: stats.ImportStatsRequest.mode:type_name -> stats.ImportMode
+	11, // 5: stats.ListSnapshotsResponse.snapshots:type_name -> stats.Snapshot
+	11, // 6: stats.DiffSnapshotsResponse.from:type_name -> stats.Snapshot
+	11, // 7: stats.DiffSnapshotsResponse.to:type_name -> stats.Snapshot
+	14, // 8: stats.DiffSnapshotsResponse.stat_diffs:type_name -> stats.StatDiff
+	17, // 9: stats.StatsAdmin.Reset:input_type -> google.protobuf.Empty
+	8,  // 10: stats.StatsAdmin.DeleteStats:input_type -> stats.DeleteStatsRequest
+	10, // 11: stats.StatsAdmin.TakeSnapshot:input_type -> stats.TakeSnapshotRequest
+	17, // 12: stats.StatsAdmin.ListSnapshots:input_type -> google.protobuf.Empty
+	13, // 13: stats.StatsAdmin.DiffSnapshots:input_type -> stats.DiffSnapshotsRequest
+	4,  // 14: stats.StatsAdmin.ExportStats:input_type -> stats.ExportStatsRequest
+	6,  // 15: stats.StatsAdmin.ImportStats:input_type -> stats.ImportStatsRequest
+	9,  // 16: stats.StatsAdmin.Reset:output_type -> stats.DeleteStatsResponse
+	9,  // 17: stats.StatsAdmin.DeleteStats:output_type -> stats.DeleteStatsResponse
+	11, // 18: stats.StatsAdmin.TakeSnapshot:output_type -> stats.Snapshot
+	12, // 19: stats.StatsAdmin.ListSnapshots:output_type -> stats.ListSnapshotsResponse
+	15, // 20: stats.StatsAdmin.DiffSnapshots:output_type -> stats.DiffSnapshotsResponse
+	5,  // 21: stats.StatsAdmin.ExportStats:output_type -> stats.StatsChunk
+	7,  // 22: stats.StatsAdmin.ImportStats:output_type -> stats.ImportStatsResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_stats_admin_proto_init() }
+func file_stats_admin_proto_init() {
+	if File_stats_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_admin_proto_rawDesc), len(file_stats_admin_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stats_admin_proto_goTypes,
+		DependencyIndexes: file_stats_admin_proto_depIdxs,
+		EnumInfos:         file_stats_admin_proto_enumTypes,
+		MessageInfos:      file_stats_admin_proto_msgTypes,
+	}.Build()
+	File_stats_admin_proto = out.File
+	file_stats_admin_proto_goTypes = nil
+	file_stats_admin_proto_depIdxs = nil
+}
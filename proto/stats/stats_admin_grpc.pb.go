@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.2
+// source: stats_admin.proto
+
+package statspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatsAdmin_Reset_FullMethodName         = "/stats.StatsAdmin/Reset"
+	StatsAdmin_DeleteStats_FullMethodName   = "/stats.StatsAdmin/DeleteStats"
+	StatsAdmin_TakeSnapshot_FullMethodName  = "/stats.StatsAdmin/TakeSnapshot"
+	StatsAdmin_ListSnapshots_FullMethodName = "/stats.StatsAdmin/ListSnapshots"
+	StatsAdmin_DiffSnapshots_FullMethodName = "/stats.StatsAdmin/DiffSnapshots"
+	StatsAdmin_ExportStats_FullMethodName   = "/stats.StatsAdmin/ExportStats"
+	StatsAdmin_ImportStats_FullMethodName   = "/stats.StatsAdmin/ImportStats"
+)
+
+// StatsAdminClient is the client API for StatsAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatsAdmin clears and snapshots the Stats service's aggregates.
+// Every call must carry "authorization: Bearer <ADMIN_TOKEN>" metadata.
+type StatsAdminClient interface {
+	// Reset deletes every aggregate, rolling window, time series and slow-log entry.
+	Reset(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeleteStatsResponse, error)
+	// DeleteStats deletes the statistics for specific values of 'n'.
+	DeleteStats(ctx context.Context, in *DeleteStatsRequest, opts ...grpc.CallOption) (*DeleteStatsResponse, error)
+	// TakeSnapshot saves the current per-n aggregates under a name.
+	TakeSnapshot(ctx context.Context, in *TakeSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	// ListSnapshots returns every saved snapshot, oldest first.
+	ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	// DiffSnapshots reports what was recorded between two snapshots.
+	DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*DiffSnapshotsResponse, error)
+	// ExportStats streams every stored aggregate in the requested format.
+	ExportStats(ctx context.Context, in *ExportStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatsChunk], error)
+	// ImportStats reads an export streamed in chunks and merges it into, or
+	// replaces, the stored aggregates.
+	ImportStats(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse], error)
+}
+
+type statsAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsAdminClient(cc grpc.ClientConnInterface) StatsAdminClient {
+	return &statsAdminClient{cc}
+}
+
+func (c *statsAdminClient) Reset(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeleteStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStatsResponse)
+	err := c.cc.Invoke(ctx, StatsAdmin_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsAdminClient) DeleteStats(ctx context.Context, in *DeleteStatsRequest, opts ...grpc.CallOption) (*DeleteStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStatsResponse)
+	err := c.cc.Invoke(ctx, StatsAdmin_DeleteStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsAdminClient) TakeSnapshot(ctx context.Context, in *TakeSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, StatsAdmin_TakeSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsAdminClient) ListSnapshots(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, StatsAdmin_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsAdminClient) DiffSnapshots(ctx context.Context, in *DiffSnapshotsRequest, opts ...grpc.CallOption) (*DiffSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffSnapshotsResponse)
+	err := c.cc.Invoke(ctx, StatsAdmin_DiffSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsAdminClient) ExportStats(ctx context.Context, in *ExportStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatsAdmin_ServiceDesc.Streams[0], StatsAdmin_ExportStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportStatsRequest, StatsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsAdmin_ExportStatsClient = grpc.ServerStreamingClient[StatsChunk]
+
+func (c *statsAdminClient) ImportStats(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StatsAdmin_ServiceDesc.Streams[1], StatsAdmin_ImportStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportStatsRequest, ImportStatsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsAdmin_ImportStatsClient = grpc.ClientStreamingClient[ImportStatsRequest, ImportStatsResponse]
+
+// StatsAdminServer is the server API for StatsAdmin service.
+// All implementations must embed UnimplementedStatsAdminServer
+// for forward compatibility.
+//
+// StatsAdmin clears and snapshots the Stats service's aggregates.
+// Every call must carry "authorization: Bearer <ADMIN_TOKEN>" metadata.
+type StatsAdminServer interface {
+	// Reset deletes every aggregate, rolling window, time series and slow-log entry.
+	Reset(context.Context, *emptypb.Empty) (*DeleteStatsResponse, error)
+	// DeleteStats deletes the statistics for specific values of 'n'.
+	DeleteStats(context.Context, *DeleteStatsRequest) (*DeleteStatsResponse, error)
+	// TakeSnapshot saves the current per-n aggregates under a name.
+	TakeSnapshot(context.Context, *TakeSnapshotRequest) (*Snapshot, error)
+	// ListSnapshots returns every saved snapshot, oldest first.
+	ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error)
+	// DiffSnapshots reports what was recorded between two snapshots.
+	DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*DiffSnapshotsResponse, error)
+	// ExportStats streams every stored aggregate in the requested format.
+	ExportStats(*ExportStatsRequest, grpc.ServerStreamingServer[StatsChunk]) error
+	// ImportStats reads an export streamed in chunks and merges it into, or
+	// replaces, the stored aggregates.
+	ImportStats(grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]) error
+	mustEmbedUnimplementedStatsAdminServer()
+}
+
+// UnimplementedStatsAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatsAdminServer struct{}
+
+func (UnimplementedStatsAdminServer) Reset(context.Context, *emptypb.Empty) (*DeleteStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedStatsAdminServer) DeleteStats(context.Context, *DeleteStatsRequest) (*DeleteStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStats not implemented")
+}
+func (UnimplementedStatsAdminServer) TakeSnapshot(context.Context, *TakeSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeSnapshot not implemented")
+}
+func (UnimplementedStatsAdminServer) ListSnapshots(context.Context, *emptypb.Empty) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedStatsAdminServer) DiffSnapshots(context.Context, *DiffSnapshotsRequest) (*DiffSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffSnapshots not implemented")
+}
+func (UnimplementedStatsAdminServer) ExportStats(*ExportStatsRequest, grpc.ServerStreamingServer[StatsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStats not implemented")
+}
+func (UnimplementedStatsAdminServer) ImportStats(grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportStats not implemented")
+}
+func (UnimplementedStatsAdminServer) mustEmbedUnimplementedStatsAdminServer() {}
+func (UnimplementedStatsAdminServer) testEmbeddedByValue()                    {}
+
+// UnsafeStatsAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsAdminServer will
+// result in compilation errors.
+type UnsafeStatsAdminServer interface {
+	mustEmbedUnimplementedStatsAdminServer()
+}
+
+func RegisterStatsAdminServer(s grpc.ServiceRegistrar, srv StatsAdminServer) {
+	// If the following call pancis, it indicates UnimplementedStatsAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatsAdmin_ServiceDesc, srv)
+}
+
+func _StatsAdmin_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsAdminServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsAdmin_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsAdminServer).Reset(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsAdmin_DeleteStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsAdminServer).DeleteStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsAdmin_DeleteStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsAdminServer).DeleteStats(ctx, req.(*DeleteStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsAdmin_TakeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsAdminServer).TakeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsAdmin_TakeSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsAdminServer).TakeSnapshot(ctx, req.(*TakeSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsAdmin_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsAdminServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsAdmin_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsAdminServer).ListSnapshots(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsAdmin_DiffSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsAdminServer).DiffSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsAdmin_DiffSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsAdminServer).DiffSnapshots(ctx, req.(*DiffSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsAdmin_ExportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatsAdminServer).ExportStats(m, &grpc.GenericServerStream[ExportStatsRequest, StatsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsAdmin_ExportStatsServer = grpc.ServerStreamingServer[StatsChunk]
+
+func _StatsAdmin_ImportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StatsAdminServer).ImportStats(&grpc.GenericServerStream[ImportStatsRequest, ImportStatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StatsAdmin_ImportStatsServer = grpc.ClientStreamingServer[ImportStatsRequest, ImportStatsResponse]
+
+// StatsAdmin_ServiceDesc is the grpc.ServiceDesc for StatsAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stats.StatsAdmin",
+	HandlerType: (*StatsAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reset",
+			Handler:    _StatsAdmin_Reset_Handler,
+		},
+		{
+			MethodName: "DeleteStats",
+			Handler:    _StatsAdmin_DeleteStats_Handler,
+		},
+		{
+			MethodName: "TakeSnapshot",
+			Handler:    _StatsAdmin_TakeSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _StatsAdmin_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "DiffSnapshots",
+			Handler:    _StatsAdmin_DiffSnapshots_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStats",
+			Handler:       _StatsAdmin_ExportStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportStats",
+			Handler:       _StatsAdmin_ImportStats_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "stats_admin.proto",
+}
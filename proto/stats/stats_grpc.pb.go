@@ -4,6 +4,9 @@
 // - protoc             v5.27.2
 // source: stats.proto
 
+// Package stats provides a gRPC service for recording and retrieving
+// statistics of Fibonacci number requests.
+
 package statspb
 
 import (
@@ -11,7 +14,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,16 +22,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Stats_RecordNo_FullMethodName = "/stats.Stats/RecordNo"
-	Stats_GetStats_FullMethodName = "/stats.Stats/GetStats"
+	Stats_RecordNo_FullMethodName     = "/stats.Stats/RecordNo"
+	Stats_RecordBatch_FullMethodName  = "/stats.Stats/RecordBatch"
+	Stats_RecordStream_FullMethodName = "/stats.Stats/RecordStream"
+	Stats_GetStats_FullMethodName     = "/stats.Stats/GetStats"
+	Stats_QueryStats_FullMethodName   = "/stats.Stats/QueryStats"
+	Stats_WatchStats_FullMethodName   = "/stats.Stats/WatchStats"
+	Stats_GetAlerts_FullMethodName    = "/stats.Stats/GetAlerts"
+	Stats_GetSlowLog_FullMethodName   = "/stats.Stats/GetSlowLog"
 )
 
 // StatsClient is the client API for Stats service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Stats defines the gRPC service for recording and retrieving statistics.
 type StatsClient interface {
+	// RecordNo records a Fibonacci request with its computation duration.
 	RecordNo(ctx context.Context, in *RecordRequest, opts ...grpc.CallOption) (*RecordResponse, error)
-	GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatsResponse, error)
+	// RecordBatch records several Fibonacci computations in one call.
+	RecordBatch(ctx context.Context, in *RecordBatchRequest, opts ...grpc.CallOption) (*RecordBatchResponse, error)
+	// RecordStream records every computation sent on the stream and replies once it closes.
+	RecordStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordRequest, RecordBatchResponse], error)
+	// GetStats returns aggregated statistics for all Fibonacci requests,
+	// either all-time or over a recent rolling window.
+	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// QueryStats returns request counts and latency percentiles bucketed over time.
+	QueryStats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error)
+	// WatchStats streams statistics as they change: every recorded request
+	// (after an initial snapshot), or a full snapshot at a fixed interval.
+	WatchStats(ctx context.Context, in *WatchStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchStatsResponse], error)
+	// GetAlerts returns the alerts raised by the configured alerting rules.
+	GetAlerts(ctx context.Context, in *GetAlertsRequest, opts ...grpc.CallOption) (*GetAlertsResponse, error)
+	// GetSlowLog returns the most recent computations slower than the slow-log threshold.
+	GetSlowLog(ctx context.Context, in *GetSlowLogRequest, opts ...grpc.CallOption) (*GetSlowLogResponse, error)
 }
 
 type statsClient struct {
@@ -50,7 +76,30 @@ func (c *statsClient) RecordNo(ctx context.Context, in *RecordRequest, opts ...g
 	return out, nil
 }
 
-func (c *statsClient) GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatsResponse, error) {
+func (c *statsClient) RecordBatch(ctx context.Context, in *RecordBatchRequest, opts ...grpc.CallOption) (*RecordBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordBatchResponse)
+	err := c.cc.Invoke(ctx, Stats_RecordBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsClient) RecordStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecordRequest, RecordBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Stats_ServiceDesc.Streams[0], Stats_RecordStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecordRequest, RecordBatchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stats_RecordStreamClient = grpc.ClientStreamingClient[RecordRequest, RecordBatchResponse]
+
+func (c *statsClient) GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatsResponse)
 	err := c.cc.Invoke(ctx, Stats_GetStats_FullMethodName, in, out, cOpts...)
@@ -60,12 +109,79 @@ func (c *statsClient) GetStats(ctx context.Context, in *emptypb.Empty, opts ...g
 	return out, nil
 }
 
+func (c *statsClient) QueryStats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryStatsResponse)
+	err := c.cc.Invoke(ctx, Stats_QueryStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsClient) WatchStats(ctx context.Context, in *WatchStatsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchStatsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Stats_ServiceDesc.Streams[1], Stats_WatchStats_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchStatsRequest, WatchStatsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stats_WatchStatsClient = grpc.ServerStreamingClient[WatchStatsResponse]
+
+func (c *statsClient) GetAlerts(ctx context.Context, in *GetAlertsRequest, opts ...grpc.CallOption) (*GetAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlertsResponse)
+	err := c.cc.Invoke(ctx, Stats_GetAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsClient) GetSlowLog(ctx context.Context, in *GetSlowLogRequest, opts ...grpc.CallOption) (*GetSlowLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSlowLogResponse)
+	err := c.cc.Invoke(ctx, Stats_GetSlowLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatsServer is the server API for Stats service.
 // All implementations must embed UnimplementedStatsServer
 // for forward compatibility.
+//
+// Stats defines the gRPC service for recording and retrieving statistics.
 type StatsServer interface {
+	// RecordNo records a Fibonacci request with its computation duration.
 	RecordNo(context.Context, *RecordRequest) (*RecordResponse, error)
-	GetStats(context.Context, *emptypb.Empty) (*StatsResponse, error)
+	// RecordBatch records several Fibonacci computations in one call.
+	RecordBatch(context.Context, *RecordBatchRequest) (*RecordBatchResponse, error)
+	// RecordStream records every computation sent on the stream and replies once it closes.
+	RecordStream(grpc.ClientStreamingServer[RecordRequest, RecordBatchResponse]) error
+	// GetStats returns aggregated statistics for all Fibonacci requests,
+	// either all-time or over a recent rolling window.
+	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// QueryStats returns request counts and latency percentiles bucketed over time.
+	QueryStats(context.Context, *QueryStatsRequest) (*QueryStatsResponse, error)
+	// WatchStats streams statistics as they change: every recorded request
+	// (after an initial snapshot), or a full snapshot at a fixed interval.
+	WatchStats(*WatchStatsRequest, grpc.ServerStreamingServer[WatchStatsResponse]) error
+	// GetAlerts returns the alerts raised by the configured alerting rules.
+	GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error)
+	// GetSlowLog returns the most recent computations slower than the slow-log threshold.
+	GetSlowLog(context.Context, *GetSlowLogRequest) (*GetSlowLogResponse, error)
 	mustEmbedUnimplementedStatsServer()
 }
 
@@ -79,9 +195,27 @@ type UnimplementedStatsServer struct{}
 func (UnimplementedStatsServer) RecordNo(context.Context, *RecordRequest) (*RecordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecordNo not implemented")
 }
-func (UnimplementedStatsServer) GetStats(context.Context, *emptypb.Empty) (*StatsResponse, error) {
+func (UnimplementedStatsServer) RecordBatch(context.Context, *RecordBatchRequest) (*RecordBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordBatch not implemented")
+}
+func (UnimplementedStatsServer) RecordStream(grpc.ClientStreamingServer[RecordRequest, RecordBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RecordStream not implemented")
+}
+func (UnimplementedStatsServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedStatsServer) QueryStats(context.Context, *QueryStatsRequest) (*QueryStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryStats not implemented")
+}
+func (UnimplementedStatsServer) WatchStats(*WatchStatsRequest, grpc.ServerStreamingServer[WatchStatsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStats not implemented")
+}
+func (UnimplementedStatsServer) GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlerts not implemented")
+}
+func (UnimplementedStatsServer) GetSlowLog(context.Context, *GetSlowLogRequest) (*GetSlowLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlowLog not implemented")
+}
 func (UnimplementedStatsServer) mustEmbedUnimplementedStatsServer() {}
 func (UnimplementedStatsServer) testEmbeddedByValue()               {}
 
@@ -121,8 +255,33 @@ func _Stats_RecordNo_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stats_RecordBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServer).RecordBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stats_RecordBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServer).RecordBatch(ctx, req.(*RecordBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stats_RecordStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StatsServer).RecordStream(&grpc.GenericServerStream[RecordRequest, RecordBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stats_RecordStreamServer = grpc.ClientStreamingServer[RecordRequest, RecordBatchResponse]
+
 func _Stats_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(StatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -134,7 +293,72 @@ func _Stats_GetStats_Handler(srv interface{}, ctx context.Context, dec func(inte
 		FullMethod: Stats_GetStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StatsServer).GetStats(ctx, req.(*emptypb.Empty))
+		return srv.(StatsServer).GetStats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stats_QueryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServer).QueryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stats_QueryStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServer).QueryStats(ctx, req.(*QueryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stats_WatchStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatsServer).WatchStats(m, &grpc.GenericServerStream[WatchStatsRequest, WatchStatsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stats_WatchStatsServer = grpc.ServerStreamingServer[WatchStatsResponse]
+
+func _Stats_GetAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServer).GetAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stats_GetAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServer).GetAlerts(ctx, req.(*GetAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stats_GetSlowLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlowLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServer).GetSlowLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stats_GetSlowLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServer).GetSlowLog(ctx, req.(*GetSlowLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -150,11 +374,38 @@ var Stats_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecordNo",
 			Handler:    _Stats_RecordNo_Handler,
 		},
+		{
+			MethodName: "RecordBatch",
+			Handler:    _Stats_RecordBatch_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _Stats_GetStats_Handler,
 		},
+		{
+			MethodName: "QueryStats",
+			Handler:    _Stats_QueryStats_Handler,
+		},
+		{
+			MethodName: "GetAlerts",
+			Handler:    _Stats_GetAlerts_Handler,
+		},
+		{
+			MethodName: "GetSlowLog",
+			Handler:    _Stats_GetSlowLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RecordStream",
+			Handler:       _Stats_RecordStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchStats",
+			Handler:       _Stats_WatchStats_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "stats.proto",
 }
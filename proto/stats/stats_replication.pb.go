@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.27.2
+// source: stats_replication.proto
+
+package statspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ReplicaState is everything one replica has recorded.
+type ReplicaState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplicaId     string                 `protobuf:"bytes,1,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`                                                                                 // Replica that recorded the aggregates
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                                                                                                     // Increases whenever the replica's aggregates change
+	Records       []*StatsRecord         `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`                                                                                                      // The replica's aggregates
+	LastSeenMs    map[string]int64       `protobuf:"bytes,4,rep,name=last_seen_ms,json=lastSeenMs,proto3" json:"last_seen_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Fibonacci instance -> when the replica last heard from it, Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaState) Reset() {
+	*x = ReplicaState{}
+	mi := &file_stats_replication_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaState) ProtoMessage() {}
+
+func (x *ReplicaState) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_replication_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaState.ProtoReflect.Descriptor instead.
+func (*ReplicaState) Descriptor() ([]byte, []int) {
+	return file_stats_replication_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReplicaState) GetReplicaId() string {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return ""
+}
+
+func (x *ReplicaState) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ReplicaState) GetRecords() []*StatsRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ReplicaState) GetLastSeenMs() map[string]int64 {
+	if x != nil {
+		return x.LastSeenMs
+	}
+	return nil
+}
+
+// GossipRequest carries the caller's view of the replicas.
+type GossipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplicaId     string                 `protobuf:"bytes,1,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`                                                         // Calling replica
+	Versions      map[string]int64       `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Replica ID -> newest version the caller holds
+	States        []*ReplicaState        `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`                                                                                // States the callee may not have yet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GossipRequest) Reset() {
+	*x = GossipRequest{}
+	mi := &file_stats_replication_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipRequest) ProtoMessage() {}
+
+func (x *GossipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_replication_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipRequest.ProtoReflect.Descriptor instead.
+func (*GossipRequest) Descriptor() ([]byte, []int) {
+	return file_stats_replication_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GossipRequest) GetReplicaId() string {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return ""
+}
+
+func (x *GossipRequest) GetVersions() map[string]int64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *GossipRequest) GetStates() []*ReplicaState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// GossipResponse carries the states the caller is missing, and the callee's
+// versions so the caller knows which of its states to push next time.
+type GossipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*ReplicaState        `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`                                                                                // States newer than the caller's versions
+	Versions      map[string]int64       `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Replica ID -> newest version the callee holds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GossipResponse) Reset() {
+	*x = GossipResponse{}
+	mi := &file_stats_replication_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipResponse) ProtoMessage() {}
+
+func (x *GossipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_replication_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipResponse.ProtoReflect.Descriptor instead.
+func (*GossipResponse) Descriptor() ([]byte, []int) {
+	return file_stats_replication_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GossipResponse) GetStates() []*ReplicaState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *GossipResponse) GetVersions() map[string]int64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+var File_stats_replication_proto protoreflect.FileDescriptor
+
+const file_stats_replication_proto_rawDesc = "" +
+	"\n" +
+	"\x17stats_replication.proto\x12\x05stats\x1a\x11stats_admin.proto\"\xfb\x01\n" +
+	"\fReplicaState\x12\x1d\n" +
+	"\n" +
+	"replica_id\x18\x01 \x01(\tR\treplicaId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12,\n" +
+	"\arecords\x18\x03 \x03(\v2\x12.stats.StatsRecordR\arecords\x12E\n" +
+	"\flast_seen_ms\x18\x04 \x03(\v2#.stats.ReplicaState.LastSeenMsEntryR\n" +
+	"lastSeenMs\x1a=\n" +
+	"\x0fLastSeenMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xd8\x01\n" +
+	"\rGossipRequest\x12\x1d\n" +
+	"\n" +
+	"replica_id\x18\x01 \x01(\tR\treplicaId\x12>\n" +
+	"\bversions\x18\x02 \x03(\v2\".stats.GossipRequest.VersionsEntryR\bversions\x12+\n" +
+	"\x06states\x18\x03 \x03(\v2\x13.stats.ReplicaStateR\x06states\x1a;\n" +
+	"\rVersionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xbb\x01\n" +
+	"\x0eGossipResponse\x12+\n" +
+	"\x06states\x18\x01 \x03(\v2\x13.stats.ReplicaStateR\x06states\x12?\n" +
+	"\bversions\x18\x02 \x03(\v2#.stats.GossipResponse.VersionsEntryR\bversions\x1a;\n" +
+	"\rVersionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x012I\n" +
+	"\x10StatsReplication\x125\n" +
+	"\x06Gossip\x12\x14.stats.GossipRequest\x1a\x15.stats.GossipResponseB$Z\"fibonacci-grpc/proto/stats;statspbb\x06proto3"
+
+var (
+	file_stats_replication_proto_rawDescOnce sync.Once
+	file_stats_replication_proto_rawDescData []byte
+)
+
+func file_stats_replication_proto_rawDescGZIP() []byte {
+	file_stats_replication_proto_rawDescOnce.Do(func() {
+		file_stats_replication_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stats_replication_proto_rawDesc), len(file_stats_replication_proto_rawDesc)))
+	})
+	return file_stats_replication_proto_rawDescData
+}
+
+var file_stats_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_stats_replication_proto_goTypes = []any{
+	(*ReplicaState)(nil),   // 0: stats.ReplicaState
+	(*GossipRequest)(nil),  // 1: stats.GossipRequest
+	(*GossipResponse)(nil), // 2: stats.GossipResponse
+	nil,                    // 3: stats.ReplicaState.LastSeenMsEntry
+	nil,                    // 4: stats.GossipRequest.VersionsEntry
+	nil,                    // 5: stats.GossipResponse.VersionsEntry
+	(*StatsRecord)(nil),    // 6: stats.StatsRecord
+}
+var file_stats_replication_proto_depIdxs = []int32{
+	6, // 0: stats.ReplicaState.records:type_name -> stats.StatsRecord
+	3, // 1: stats.ReplicaState.last_seen_ms:type_name -> stats.ReplicaState.LastSeenMsEntry
+	4, // 2: stats.GossipRequest.versions:type_name -> stats.GossipRequest.VersionsEntry
+	0, // 3: stats.GossipRequest.states:type_name -> stats.ReplicaState
+	0, // 4: stats.GossipResponse.states:type_name -> stats.ReplicaState
+	5, // 5: stats.GossipResponse.versions:type_name -> stats.GossipResponse.VersionsEntry
+	1, // 6: stats.StatsReplication.Gossip:input_type -> stats.GossipRequest
+	2, // 7: stats.StatsReplication.Gossip:output_type -> stats.GossipResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_stats_replication_proto_init() }
+func file_stats_replication_proto_init() {
+	if File_stats_replication_proto != nil {
+		return
+	}
+	file_stats_admin_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_replication_proto_rawDesc), len(file_stats_replication_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stats_replication_proto_goTypes,
+		DependencyIndexes: file_stats_replication_proto_depIdxs,
+		MessageInfos:      file_stats_replication_proto_msgTypes,
+	}.Build()
+	File_stats_replication_proto = out.File
+	file_stats_replication_proto_goTypes = nil
+	file_stats_replication_proto_depIdxs = nil
+}
@@ -0,0 +1,135 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.2
+// source: stats_replication.proto
+
+package statspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StatsReplication_Gossip_FullMethodName = "/stats.StatsReplication/Gossip"
+)
+
+// StatsReplicationClient is the client API for StatsReplication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StatsReplication is spoken between Stats replicas so each can report the
+// global statistics. Every replica owns a versioned state holding the
+// aggregates it recorded; replicas exchange states and keep the newest
+// version of each, so they converge whatever order gossip arrives in.
+type StatsReplicationClient interface {
+	// Gossip pushes the caller's states and returns the states the callee
+	// holds that are newer than the caller's versions.
+	Gossip(ctx context.Context, in *GossipRequest, opts ...grpc.CallOption) (*GossipResponse, error)
+}
+
+type statsReplicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatsReplicationClient(cc grpc.ClientConnInterface) StatsReplicationClient {
+	return &statsReplicationClient{cc}
+}
+
+func (c *statsReplicationClient) Gossip(ctx context.Context, in *GossipRequest, opts ...grpc.CallOption) (*GossipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GossipResponse)
+	err := c.cc.Invoke(ctx, StatsReplication_Gossip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatsReplicationServer is the server API for StatsReplication service.
+// All implementations must embed UnimplementedStatsReplicationServer
+// for forward compatibility.
+//
+// StatsReplication is spoken between Stats replicas so each can report the
+// global statistics. Every replica owns a versioned state holding the
+// aggregates it recorded; replicas exchange states and keep the newest
+// version of each, so they converge whatever order gossip arrives in.
+type StatsReplicationServer interface {
+	// Gossip pushes the caller's states and returns the states the callee
+	// holds that are newer than the caller's versions.
+	Gossip(context.Context, *GossipRequest) (*GossipResponse, error)
+	mustEmbedUnimplementedStatsReplicationServer()
+}
+
+// UnimplementedStatsReplicationServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatsReplicationServer struct{}
+
+func (UnimplementedStatsReplicationServer) Gossip(context.Context, *GossipRequest) (*GossipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Gossip not implemented")
+}
+func (UnimplementedStatsReplicationServer) mustEmbedUnimplementedStatsReplicationServer() {}
+func (UnimplementedStatsReplicationServer) testEmbeddedByValue()                          {}
+
+// UnsafeStatsReplicationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatsReplicationServer will
+// result in compilation errors.
+type UnsafeStatsReplicationServer interface {
+	mustEmbedUnimplementedStatsReplicationServer()
+}
+
+func RegisterStatsReplicationServer(s grpc.ServiceRegistrar, srv StatsReplicationServer) {
+	// If the following call pancis, it indicates UnimplementedStatsReplicationServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatsReplication_ServiceDesc, srv)
+}
+
+func _StatsReplication_Gossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsReplicationServer).Gossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatsReplication_Gossip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsReplicationServer).Gossip(ctx, req.(*GossipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatsReplication_ServiceDesc is the grpc.ServiceDesc for StatsReplication service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatsReplication_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stats.StatsReplication",
+	HandlerType: (*StatsReplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Gossip",
+			Handler:    _StatsReplication_Gossip_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stats_replication.proto",
+}
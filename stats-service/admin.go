@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// adminMethodPrefix matches every StatsAdmin RPC.
+const adminMethodPrefix = "/stats.StatsAdmin/"
+
+// maxSnapshots bounds how many named snapshots are held in memory.
+const maxSnapshots = 100
+
+// adminToken is the bearer token required by StatsAdmin. If unset, the admin API is disabled.
+var adminToken = os.Getenv("ADMIN_TOKEN")
+
+// statsAdminServer implements the StatsAdmin gRPC service.
+// Snapshots are held in memory by each Stats instance.
+type statsAdminServer struct {
+	pb.UnimplementedStatsAdminServer
+	stats *statsService
+
+	mu        sync.Mutex
+	snapshots []*snapshot // oldest first
+}
+
+// snapshot is a named copy of the per-n aggregates.
+type snapshot struct {
+	name    string
+	takenAt time.Time
+	entries map[Key]*Entry
+}
+
+// AdminAuthInterceptor rejects StatsAdmin calls that don't carry the admin token
+// and StatsReplication calls that don't carry the peer token.
+// Other services pass through untouched.
+func AdminAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorizeAdmin(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	if err := authorizePeer(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AdminAuthStreamInterceptor is AdminAuthInterceptor for streaming RPCs.
+func AdminAuthStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorizeAdmin(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	if err := authorizePeer(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authorizeAdmin checks the admin token on calls to StatsAdmin methods.
+func authorizeAdmin(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, adminMethodPrefix) {
+		return nil
+	}
+	if adminToken == "" {
+		return status.Error(codes.PermissionDenied, "stats admin disabled (ADMIN_TOKEN not set)")
+	}
+	if !hasBearerToken(ctx, adminToken) {
+		log.Printf("Rejected unauthenticated admin call to %s", method)
+		return status.Error(codes.Unauthenticated, "invalid admin credentials")
+	}
+	return nil
+}
+
+// authorizePeer checks the peer token on calls to StatsReplication methods.
+func authorizePeer(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, replicationMethodPrefix) {
+		return nil
+	}
+	if peerToken == "" {
+		return status.Error(codes.PermissionDenied, "stats replication disabled (STATS_PEER_TOKEN not set)")
+	}
+	if !hasBearerToken(ctx, peerToken) {
+		log.Printf("Rejected unauthenticated replication call to %s", method)
+		return status.Error(codes.Unauthenticated, "invalid peer credentials")
+	}
+	return nil
+}
+
+// hasBearerToken reports whether the call carries token in its authorization metadata.
+func hasBearerToken(ctx context.Context, token string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(v, "Bearer ")), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset deletes every aggregate, rolling window, time series and slow-log entry.
+func (a *statsAdminServer) Reset(context.Context, *emptypb.Empty) (*pb.DeleteStatsResponse, error) {
+	if err := a.stats.checkUnreplicated("reset"); err != nil {
+		return nil, err
+	}
+	all := func(Key) bool { return true }
+	a.stats.limiter.reset()
+	a.stats.clients.reset()
+	a.stats.instances.reset()
+	a.stats.slowLog.reset()
+	a.stats.windows.Forget(all)
+	a.stats.series.Forget(func(int) bool { return true })
+	res, err := a.deleteKeys(all)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Reset stats: deleted %d aggregates (%d requests)", res.Deleted, res.DeletedRequests)
+	return res, nil
+}
+
+// DeleteStats deletes the statistics for specific values of 'n'.
+func (a *statsAdminServer) DeleteStats(_ context.Context, in *pb.DeleteStatsRequest) (*pb.DeleteStatsResponse, error) {
+	if len(in.GetN()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no n given")
+	}
+	if err := a.stats.checkUnreplicated("deleting stats"); err != nil {
+		return nil, err
+	}
+	ns := make(map[int]bool, len(in.GetN()))
+	for _, n := range in.GetN() {
+		ns[int(n)] = true
+	}
+	match := func(k Key) bool { return ns[k.N] }
+
+	a.stats.limiter.forget(match)
+	a.stats.windows.Forget(match)
+	a.stats.series.Forget(func(n int) bool { return ns[n] })
+	res, err := a.deleteKeys(match)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Deleted stats for n=%v: %d aggregates (%d requests)", in.GetN(), res.Deleted, res.DeletedRequests)
+	return res, nil
+}
+
+// deleteKeys removes every stored aggregate whose key matches.
+func (a *statsAdminServer) deleteKeys(match func(Key) bool) (*pb.DeleteStatsResponse, error) {
+	entries, err := a.stats.store.Entries()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "loading stats: %v", err)
+	}
+	res := &pb.DeleteStatsResponse{}
+	for k := range entries {
+		if !match(k) {
+			continue
+		}
+		e, err := a.stats.store.Delete(k)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "deleting stats for n=%d: %v", k.N, err)
+		}
+		if e != nil {
+			res.Deleted++
+			res.DeletedRequests += int32(e.Count)
+		}
+	}
+	return res, nil
+}
+
+// TakeSnapshot saves the current per-n aggregates under a name.
+func (a *statsAdminServer) TakeSnapshot(_ context.Context, in *pb.TakeSnapshotRequest) (*pb.Snapshot, error) {
+	if in.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "snapshot name is required")
+	}
+	snap, err := a.current()
+	if err != nil {
+		return nil, err
+	}
+	snap.name = in.GetName()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.find(snap.name) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "snapshot %q already exists", snap.name)
+	}
+	if len(a.snapshots) >= maxSnapshots {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many snapshots (max %d)", maxSnapshots)
+	}
+	a.snapshots = append(a.snapshots, snap)
+	log.Printf("Took stats snapshot %q", snap.name)
+	return snap.proto(), nil
+}
+
+// ListSnapshots returns every saved snapshot, oldest first.
+func (a *statsAdminServer) ListSnapshots(context.Context, *emptypb.Empty) (*pb.ListSnapshotsResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := &pb.ListSnapshotsResponse{}
+	for _, snap := range a.snapshots {
+		res.Snapshots = append(res.Snapshots, snap.proto())
+	}
+	return res, nil
+}
+
+// DiffSnapshots reports what was recorded between two snapshots, or between
+// a snapshot and the current statistics.
+func (a *statsAdminServer) DiffSnapshots(_ context.Context, in *pb.DiffSnapshotsRequest) (*pb.DiffSnapshotsResponse, error) {
+	a.mu.Lock()
+	from, to := a.find(in.GetFrom()), a.find(in.GetTo())
+	a.mu.Unlock()
+	if from == nil {
+		return nil, status.Errorf(codes.NotFound, "snapshot %q not found", in.GetFrom())
+	}
+	if in.GetTo() == "" {
+		var err error
+		if to, err = a.current(); err != nil {
+			return nil, err
+		}
+	} else if to == nil {
+		return nil, status.Errorf(codes.NotFound, "snapshot %q not found", in.GetTo())
+	}
+
+	ns := make(map[int]bool)
+	for k := range from.entries {
+		ns[k.N] = true
+	}
+	for k := range to.entries {
+		ns[k.N] = true
+	}
+	sorted := make([]int, 0, len(ns))
+	for n := range ns {
+		sorted = append(sorted, n)
+	}
+	sort.Ints(sorted)
+
+	res := &pb.DiffSnapshotsResponse{From: from.proto(), To: to.proto()}
+	for _, n := range sorted {
+		var before, after Entry
+		if e := from.entries[Key{N: n}]; e != nil {
+			before = *e
+		}
+		if e := to.entries[Key{N: n}]; e != nil {
+			after = *e
+		}
+		count := after.Count - before.Count
+		errors := after.Errors - before.Errors
+		if count == 0 && errors == 0 {
+			continue
+		}
+		diff := &pb.StatDiff{N: int32(n), RequestCount: int32(count), ErrorCount: int32(errors)}
+		if count > 0 {
+			diff.AverageTimeMs = float64(after.TotalTime-before.TotalTime) / float64(count) / float64(time.Millisecond)
+		}
+		res.TotalRequests += int32(count)
+		res.StatDiffs = append(res.StatDiffs, diff)
+	}
+	return res, nil
+}
+
+// current returns an unnamed snapshot of the statistics right now, across replicas.
+func (a *statsAdminServer) current() (*snapshot, error) {
+	entries, err := a.stats.entries()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "loading stats: %v", err)
+	}
+	return &snapshot{takenAt: time.Now(), entries: groupEntries(entries, []string{dimN}, nil)}, nil
+}
+
+// find returns the snapshot with the given name, or nil. The caller holds a.mu.
+func (a *statsAdminServer) find(name string) *snapshot {
+	for _, snap := range a.snapshots {
+		if snap.name == name {
+			return snap
+		}
+	}
+	return nil
+}
+
+// proto converts the snapshot's summary into its wire form.
+func (s *snapshot) proto() *pb.Snapshot {
+	res := &pb.Snapshot{Name: s.name, TakenAtMs: s.takenAt.UnixMilli(), Ns: int32(len(s.entries))}
+	for _, e := range s.entries {
+		res.TotalRequests += int32(e.Count)
+	}
+	return res
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+// alertRule is one rule of the ALERT_RULES_FILE, e.g. "p99 latency for any n
+// above 50ms over 5m":
+//
+//	{"name": "slow-p99", "metric": "p99_ms", "op": ">", "threshold": 50, "window": "5m", "group_by": ["n"]}
+type alertRule struct {
+	Name        string            `json:"name"`
+	Metric      string            `json:"metric"`      // key of alertMetrics
+	Op          string            `json:"op"`          // key of alertOps
+	Threshold   float64           `json:"threshold"`   // value the metric is compared against
+	Window      string            `json:"window"`      // rolling window, empty for all-time
+	GroupBy     []string          `json:"group_by"`    // one alert per group; empty for all records together
+	Filters     map[string]string `json:"filters"`     // only evaluate matching records
+	MinCount    int64             `json:"min_count"`   // skip groups with fewer requests
+	For         string            `json:"for"`         // how long the condition must hold before firing
+	Description string            `json:"description"` // free text passed on with the alert
+
+	forDuration time.Duration
+}
+
+// alertMetrics maps rule metric names to the value they read from a group's stats.
+var alertMetrics = map[string]func(*pb.FibonacciStat) float64{
+	"count":      func(s *pb.FibonacciStat) float64 { return float64(s.RequestCount) },
+	"qps":        func(s *pb.FibonacciStat) float64 { return s.Qps },
+	"errors":     func(s *pb.FibonacciStat) float64 { return float64(s.ErrorCount) },
+	"error_rate": func(s *pb.FibonacciStat) float64 { return s.ErrorRate },
+	"average_ms": func(s *pb.FibonacciStat) float64 { return s.AverageTimeMs },
+	"p50_ms":     func(s *pb.FibonacciStat) float64 { return s.P50Us / 1000 },
+	"p90_ms":     func(s *pb.FibonacciStat) float64 { return s.P90Us / 1000 },
+	"p99_ms":     func(s *pb.FibonacciStat) float64 { return s.P99Us / 1000 },
+	"p999_ms":    func(s *pb.FibonacciStat) float64 { return s.P999Us / 1000 },
+	"max_ms":     func(s *pb.FibonacciStat) float64 { return s.MaxUs / 1000 },
+}
+
+// alertOps maps rule comparisons to their implementation.
+var alertOps = map[string]func(v, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+}
+
+// loadAlertRules reads and validates a rules file of the form {"rules": [...]}.
+func loadAlertRules(path string) ([]*alertRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Rules []*alertRule `json:"rules"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(file.Rules))
+	for i, rule := range file.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if alertMetrics[rule.Metric] == nil {
+			return nil, fmt.Errorf("rule %q: unknown metric %q", rule.Name, rule.Metric)
+		}
+		if alertOps[rule.Op] == nil {
+			return nil, fmt.Errorf("rule %q: unknown op %q (want >, >=, < or <=)", rule.Name, rule.Op)
+		}
+		if _, ok := windowSpans[rule.Window]; rule.Window != "" && !ok {
+			return nil, fmt.Errorf("rule %q: unknown window %q (want 1m, 5m, 1h or 24h)", rule.Name, rule.Window)
+		}
+		if err := validateDimensions(rule.GroupBy, rule.Filters); err != nil {
+			return nil, fmt.Errorf("rule %q: %v", rule.Name, err)
+		}
+		if rule.For != "" {
+			if rule.forDuration, err = time.ParseDuration(rule.For); err != nil {
+				return nil, fmt.Errorf("rule %q: invalid for: %v", rule.Name, err)
+			}
+		}
+	}
+	return file.Rules, nil
+}
+
+// alerter periodically evaluates alerting rules against the aggregated stats
+// and notifies a webhook when alerts fire and resolve. A nil alerter has no rules.
+type alerter struct {
+	stats   *statsService
+	rules   []*alertRule
+	webhook string
+	client  *http.Client
+
+	mu          sync.Mutex
+	active      map[alertID]*activeAlert
+	evaluatedAt time.Time
+}
+
+// alertID identifies an alert: a rule and the group it fired for.
+type alertID struct {
+	rule  *alertRule
+	group Key
+}
+
+// activeAlert is an alert whose condition currently holds.
+type activeAlert struct {
+	rule    *alertRule
+	group   Key
+	value   float64
+	since   time.Time
+	firedAt time.Time // zero while pending
+}
+
+// alertNotification is one alert in a webhook payload.
+type alertNotification struct {
+	Status      string            `json:"status"` // "firing" or "resolved"
+	Rule        string            `json:"rule"`
+	Labels      map[string]string `json:"labels"`
+	Metric      string            `json:"metric"`
+	Op          string            `json:"op"`
+	Threshold   float64           `json:"threshold"`
+	Value       float64           `json:"value"` // at the last evaluation the condition held
+	Window      string            `json:"window,omitempty"`
+	Description string            `json:"description,omitempty"`
+	StartsAt    time.Time         `json:"starts_at"`
+	EndsAt      *time.Time        `json:"ends_at,omitempty"`
+}
+
+// newAlerter returns an alerter for rules, notifying webhook if set.
+func newAlerter(stats *statsService, rules []*alertRule, webhook string) *alerter {
+	return &alerter{
+		stats:   stats,
+		rules:   rules,
+		webhook: webhook,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		active:  make(map[alertID]*activeAlert),
+	}
+}
+
+// run evaluates the rules every interval.
+func (a *alerter) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		a.evaluate(now)
+	}
+}
+
+// evaluate checks every rule, moving alerts between pending, firing and
+// resolved, and notifies the webhook of alerts that fired or resolved.
+func (a *alerter) evaluate(now time.Time) {
+	holding := make(map[alertID]float64)
+	failed := make(map[*alertRule]bool)
+	for _, rule := range a.rules {
+		if err := a.check(rule, holding); err != nil {
+			log.Printf("Failed to evaluate alert rule %q: %v", rule.Name, err)
+			failed[rule] = true
+		}
+	}
+
+	var changes []alertNotification
+	a.mu.Lock()
+	for id, value := range holding {
+		al := a.active[id]
+		if al == nil {
+			al = &activeAlert{rule: id.rule, group: id.group, since: now}
+			a.active[id] = al
+		}
+		al.value = value
+		if al.firedAt.IsZero() && now.Sub(al.since) >= al.rule.forDuration {
+			al.firedAt = now
+			log.Printf("Alert %q firing for %v: %s = %.4g", al.rule.Name, al.labels(), al.rule.Metric, value)
+			changes = append(changes, al.notification("firing", nil))
+		}
+	}
+	for id, al := range a.active {
+		// Alerts of a rule that couldn't be evaluated keep their state
+		if _, ok := holding[id]; ok || failed[id.rule] {
+			continue
+		}
+		delete(a.active, id)
+		if !al.firedAt.IsZero() {
+			log.Printf("Alert %q resolved for %v", al.rule.Name, al.labels())
+			changes = append(changes, al.notification("resolved", &now))
+		}
+	}
+	a.evaluatedAt = now
+	a.mu.Unlock()
+
+	if len(changes) > 0 && a.webhook != "" {
+		if err := a.notify(changes); err != nil {
+			log.Printf("Failed to notify alert webhook: %v", err)
+		}
+	}
+}
+
+// check adds the value of every group for which rule's condition holds to holding.
+func (a *alerter) check(rule *alertRule, holding map[alertID]float64) error {
+	var entries map[Key]*Entry
+	var span time.Duration
+	var err error
+	if rule.Window == "" {
+		entries, err = a.stats.entries()
+	} else {
+		entries, span, err = a.stats.windows.Entries(rule.Window)
+	}
+	if err != nil {
+		return err
+	}
+	for group, e := range groupEntries(entries, rule.GroupBy, rule.Filters) {
+		if e.Count == 0 || e.Count < rule.MinCount {
+			continue
+		}
+		stat := fibonacciStat(group.N, e)
+		stat.Qps = rate(e.Count, span)
+		if value := alertMetrics[rule.Metric](stat); alertOps[rule.Op](value, rule.Threshold) {
+			holding[alertID{rule: rule, group: group}] = value
+		}
+	}
+	return nil
+}
+
+// notify posts alert changes to the webhook as {"alerts": [...]}.
+func (a *alerter) notify(changes []alertNotification) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	enc.SetEscapeHTML(false) // keep ops such as ">" readable
+	if err := enc.Encode(map[string]any{"alerts": changes}); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhook, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", res.Status)
+	}
+	return nil
+}
+
+// list returns the active alerts, firing first, then by rule and group.
+func (a *alerter) list(includePending bool) *pb.GetAlertsResponse {
+	res := &pb.GetAlertsResponse{}
+	if a == nil {
+		return res
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res.Rules = int32(len(a.rules))
+	if !a.evaluatedAt.IsZero() {
+		res.EvaluatedAtMs = a.evaluatedAt.UnixMilli()
+	}
+	var alerts []*activeAlert
+	for _, al := range a.active {
+		if !al.firedAt.IsZero() || includePending {
+			alerts = append(alerts, al)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		x, y := alerts[i], alerts[j]
+		if x.firedAt.IsZero() != y.firedAt.IsZero() {
+			return !x.firedAt.IsZero()
+		}
+		if x.rule.Name != y.rule.Name {
+			return x.rule.Name < y.rule.Name
+		}
+		return x.group.less(y.group)
+	})
+	for _, al := range alerts {
+		res.Alerts = append(res.Alerts, al.proto())
+	}
+	return res
+}
+
+// labels returns the group the alert is for as dimension values.
+func (al *activeAlert) labels() map[string]string {
+	return al.group.labels(al.rule.GroupBy)
+}
+
+// notification converts the alert into its webhook form.
+func (al *activeAlert) notification(status string, endsAt *time.Time) alertNotification {
+	return alertNotification{
+		Status:      status,
+		Rule:        al.rule.Name,
+		Labels:      al.labels(),
+		Metric:      al.rule.Metric,
+		Op:          al.rule.Op,
+		Threshold:   al.rule.Threshold,
+		Value:       al.value,
+		Window:      al.rule.Window,
+		Description: al.rule.Description,
+		StartsAt:    al.firedAt,
+		EndsAt:      endsAt,
+	}
+}
+
+// proto converts the alert into its wire form.
+func (al *activeAlert) proto() *pb.Alert {
+	res := &pb.Alert{
+		Rule:          al.rule.Name,
+		Labels:        al.labels(),
+		Metric:        al.rule.Metric,
+		Op:            al.rule.Op,
+		Threshold:     al.rule.Threshold,
+		Value:         al.value,
+		Window:        al.rule.Window,
+		State:         pb.AlertState_ALERT_STATE_PENDING,
+		ActiveSinceMs: al.since.UnixMilli(),
+		Description:   al.rule.Description,
+	}
+	if !al.firedAt.IsZero() {
+		res.State = pb.AlertState_ALERT_STATE_FIRING
+		res.FiredAtMs = al.firedAt.UnixMilli()
+	}
+	return res
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+func TestLoadAlertRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string // substring of the error; empty for valid files
+	}{
+		{name: "valid", file: `{"rules": [{"name": "slow-p99", "metric": "p99_ms", "op": ">", "threshold": 50, "window": "5m", "group_by": ["n"], "for": "1m"}]}`},
+		{name: "all-time rule", file: `{"rules": [{"name": "errors", "metric": "errors", "op": ">=", "threshold": 1}]}`},
+		{name: "no rules", file: `{"rules": []}`},
+		{name: "not json", file: `rules:`, wantErr: "invalid character"},
+		{name: "unknown field", file: `{"rules": [{"name": "a", "metric": "count", "op": ">", "treshold": 1}]}`, wantErr: "unknown field"},
+		{name: "missing name", file: `{"rules": [{"metric": "count", "op": ">"}]}`, wantErr: "rule 0 has no name"},
+		{name: "duplicate name", file: `{"rules": [{"name": "a", "metric": "count", "op": ">"}, {"name": "a", "metric": "count", "op": "<"}]}`, wantErr: `duplicate rule "a"`},
+		{name: "unknown metric", file: `{"rules": [{"name": "a", "metric": "p42_ms", "op": ">"}]}`, wantErr: "unknown metric"},
+		{name: "unknown op", file: `{"rules": [{"name": "a", "metric": "count", "op": "=="}]}`, wantErr: "unknown op"},
+		{name: "unknown window", file: `{"rules": [{"name": "a", "metric": "count", "op": ">", "window": "2m"}]}`, wantErr: "unknown window"},
+		{name: "unknown dimension", file: `{"rules": [{"name": "a", "metric": "count", "op": ">", "group_by": ["color"]}]}`, wantErr: "color"},
+		{name: "invalid for", file: `{"rules": [{"name": "a", "metric": "count", "op": ">", "for": "soon"}]}`, wantErr: "invalid for"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			rules, err := loadAlertRules(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("loadAlertRules: %v", err)
+				}
+				for _, rule := range rules {
+					if rule.For != "" && rule.forDuration == 0 {
+						t.Errorf("rule %q: for %q not parsed", rule.Name, rule.For)
+					}
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadAlertRules error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// webhookRecorder is an alert webhook that keeps every notification posted to it.
+type webhookRecorder struct {
+	mu     sync.Mutex
+	alerts []alertNotification
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Alerts []alertNotification `json:"alerts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.mu.Lock()
+	w.alerts = append(w.alerts, body.Alerts...)
+	w.mu.Unlock()
+}
+
+func TestAlerterLifecycle(t *testing.T) {
+	hook := &webhookRecorder{}
+	srv := httptest.NewServer(hook)
+	defer srv.Close()
+
+	s := &statsService{store: NewMemoryStore(), windows: NewRollingWindows()}
+	rule := &alertRule{Name: "errors", Metric: "error_rate", Op: ">", Threshold: 0.5, GroupBy: []string{dimN}, For: "1m", forDuration: time.Minute}
+	a := newAlerter(s, []*alertRule{rule}, srv.URL)
+	record := func(status string, count int64) {
+		if err := s.store.Record(Event{Key: Key{N: 7, Status: status}, Duration: time.Millisecond, Weight: count}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	states := func() []pb.AlertState {
+		var res []pb.AlertState
+		for _, al := range a.list(true).GetAlerts() {
+			res = append(res, al.GetState())
+		}
+		return res
+	}
+
+	start := time.Unix(1700000000, 0)
+	steps := []struct {
+		name   string
+		record func()
+		after  time.Duration // since start
+		want   []pb.AlertState
+	}{
+		{name: "condition doesn't hold", record: func() { record("OK", 1) }, want: nil},
+		{name: "condition holds, pending", record: func() { record("Internal", 4) }, after: 10 * time.Second, want: []pb.AlertState{pb.AlertState_ALERT_STATE_PENDING}},
+		{name: "held for less than for", after: 30 * time.Second, want: []pb.AlertState{pb.AlertState_ALERT_STATE_PENDING}},
+		{name: "held for for, firing", after: 70 * time.Second, want: []pb.AlertState{pb.AlertState_ALERT_STATE_FIRING}},
+		{name: "still firing", after: 90 * time.Second, want: []pb.AlertState{pb.AlertState_ALERT_STATE_FIRING}},
+		{name: "condition stops holding, resolved", record: func() { record("OK", 20) }, after: 100 * time.Second, want: nil},
+		{name: "stays resolved", after: 120 * time.Second, want: nil},
+	}
+	for _, step := range steps {
+		if step.record != nil {
+			step.record()
+		}
+		a.evaluate(start.Add(step.after))
+		if got := states(); !slices.Equal(got, step.want) {
+			t.Errorf("%s: alerts %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.alerts) != 2 {
+		t.Fatalf("webhook received %d notifications, want 2: %+v", len(hook.alerts), hook.alerts)
+	}
+	firing, resolved := hook.alerts[0], hook.alerts[1]
+	if firing.Status != "firing" || firing.Rule != "errors" || firing.Labels[dimN] != "7" || firing.EndsAt != nil {
+		t.Errorf("first notification = %+v, want errors firing for n=7", firing)
+	}
+	if !firing.StartsAt.Equal(start.Add(70 * time.Second)) {
+		t.Errorf("fired at %v, want %v", firing.StartsAt, start.Add(70*time.Second))
+	}
+	if resolved.Status != "resolved" || resolved.Rule != "errors" || resolved.EndsAt == nil || !resolved.EndsAt.Equal(start.Add(100*time.Second)) {
+		t.Errorf("second notification = %+v, want errors resolved at %v", resolved, start.Add(100*time.Second))
+	}
+}
+
+func TestAlerterPendingNeverNotifies(t *testing.T) {
+	hook := &webhookRecorder{}
+	srv := httptest.NewServer(hook)
+	defer srv.Close()
+
+	s := &statsService{store: NewMemoryStore(), windows: NewRollingWindows()}
+	rule := &alertRule{Name: "busy", Metric: "count", Op: ">=", Threshold: 2, For: "1m", forDuration: time.Minute}
+	a := newAlerter(s, []*alertRule{rule}, srv.URL)
+	if err := s.store.Record(Event{Key: Key{N: 3}, Duration: time.Millisecond, Weight: 2}); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(1700000000, 0)
+	a.evaluate(start)
+	if got := a.list(false).GetAlerts(); len(got) != 0 {
+		t.Errorf("pending alert listed as firing: %v", got)
+	}
+	// The condition stops holding before the alert fires
+	a.stats.store = NewMemoryStore()
+	a.evaluate(start.Add(30 * time.Second))
+	if got := a.list(true).GetAlerts(); len(got) != 0 {
+		t.Errorf("alerts after the condition stopped holding: %v", got)
+	}
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.alerts) != 0 {
+		t.Errorf("webhook received %+v for an alert that never fired", hook.alerts)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"sync"
+)
+
+// maxClientIDLen bounds a recorded client ID; longer IDs are truncated.
+const maxClientIDLen = 64
+
+// otherClient is the client recorded for IDs beyond STATS_MAX_CLIENTS.
+const otherClient = "other"
+
+// clientSet bounds the client dimension, which callers pick freely through
+// X-Client-ID. The first max distinct client IDs keep their own value and
+// later ones are recorded as otherClient, so a client keeps its value once it
+// has one. A nil clientSet records every client ID.
+type clientSet struct {
+	max int
+
+	mu   sync.RWMutex
+	seen map[string]bool
+}
+
+// newClientSet returns a set admitting max client IDs, or nil for no limit.
+func newClientSet(max int) *clientSet {
+	if max <= 0 {
+		return nil
+	}
+	return &clientSet{max: max, seen: make(map[string]bool)}
+}
+
+// label returns the client value to record for id.
+func (c *clientSet) label(id string) string {
+	if len(id) > maxClientIDLen {
+		// Drop a rune cut in half along with the rest
+		id = strings.ToValidUTF8(id[:maxClientIDLen], "")
+	}
+	if c == nil || id == "" {
+		return id
+	}
+	c.mu.RLock()
+	known := c.seen[id]
+	c.mu.RUnlock()
+	if known {
+		return id
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.seen[id] {
+		if len(c.seen) >= c.max {
+			return otherClient
+		}
+		c.seen[id] = true
+	}
+	return id
+}
+
+// seed admits the clients of the stored aggregates, e.g. after a restart with a
+// persistent store, so they keep their value.
+func (c *clientSet) seed(entries map[Key]*Entry) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range entries {
+		if k.Client != "" && k.Client != otherClient {
+			c.seen[k.Client] = true
+		}
+	}
+}
+
+// reset forgets every client, e.g. after the stats were reset.
+func (c *clientSet) reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.seen = make(map[string]bool)
+	c.mu.Unlock()
+}
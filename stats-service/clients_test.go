@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestClientSetLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		ids  []string
+		want []string
+	}{
+		{name: "no limit", max: 0, ids: []string{"a", "b", "c"}, want: []string{"a", "b", "c"}},
+		{name: "later clients are other", max: 2, ids: []string{"a", "b", "c", "a", "d", "b"}, want: []string{"a", "b", otherClient, "a", otherClient, "b"}},
+		{name: "no client takes no slot", max: 1, ids: []string{"", "a", "", "b"}, want: []string{"", "a", "", otherClient}},
+		{name: "long ID truncated", max: 0, ids: []string{strings.Repeat("x", 100)}, want: []string{strings.Repeat("x", maxClientIDLen)}},
+		{name: "truncated IDs share a slot", max: 1, ids: []string{strings.Repeat("x", 70), strings.Repeat("x", 80)}, want: []string{strings.Repeat("x", maxClientIDLen), strings.Repeat("x", maxClientIDLen)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClientSet(tt.max)
+			for i, id := range tt.ids {
+				if got := c.label(id); got != tt.want[i] {
+					t.Errorf("label #%d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClientSetTruncatesOnRuneBoundary(t *testing.T) {
+	id := strings.Repeat("x", maxClientIDLen-1) + "é"
+	got := newClientSet(0).label(id)
+	if !utf8.ValidString(got) || len(got) > maxClientIDLen {
+		t.Errorf("label(%q) = %q, want valid UTF-8 of at most %d bytes", id, got, maxClientIDLen)
+	}
+}
+
+func TestClientSetSeed(t *testing.T) {
+	c := newClientSet(2)
+	c.seed(map[Key]*Entry{{N: 1, Client: "web"}: {}, {N: 2, Client: "cli"}: {}, {N: 3}: {}})
+	if got := c.label("web"); got != "web" {
+		t.Errorf("seeded client labelled %q, want web", got)
+	}
+	if got := c.label("new"); got != otherClient {
+		t.Errorf("client beyond the seeded ones labelled %q, want %q", got, otherClient)
+	}
+	c.reset()
+	if got := c.label("new"); got != "new" {
+		t.Errorf("client after reset labelled %q, want new", got)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// errDuplicate is returned by record for an event ID that was already recorded.
+var errDuplicate = errors.New("duplicate event")
+
+// dedupWindow remembers recently recorded event IDs so a record retried after
+// a timeout (whose first attempt may have been applied) is counted once.
+// It holds at most size IDs for at most ttl; a retry arriving later than that
+// is counted again. A nil window records every event.
+type dedupWindow struct {
+	ttl     time.Duration
+	shards  []dedupShard
+	dropped atomic.Int64
+}
+
+// dedupShard is the part of a dedupWindow holding the IDs that hash to it.
+// IDs expire oldest first from a ring.
+type dedupShard struct {
+	mu   sync.Mutex
+	seen map[string]uint64 // ID -> sequence number of its ring slot
+	ring []dedupSlot
+	head int    // oldest slot
+	size int    // slots in use
+	seq  uint64 // sequence number of the next slot
+}
+
+// dedupSlot is one remembered ID.
+type dedupSlot struct {
+	id  string
+	seq uint64
+	at  time.Time
+}
+
+// newDedupWindow returns a window remembering up to size IDs for ttl, or nil
+// if either is not positive.
+func newDedupWindow(size int, ttl time.Duration) *dedupWindow {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	// Small windows use fewer shards so every shard holds at least one ID,
+	// and the shards' capacities add up to size
+	w := &dedupWindow{ttl: ttl, shards: make([]dedupShard, min(size, recordShards))}
+	for i := range w.shards {
+		perShard := size / len(w.shards)
+		if i < size%len(w.shards) {
+			perShard++
+		}
+		w.shards[i].seen = make(map[string]uint64, perShard)
+		w.shards[i].ring = make([]dedupSlot, perShard)
+	}
+	return w
+}
+
+// shard returns the shard holding id.
+func (w *dedupWindow) shard(id string) *dedupShard {
+	return &w.shards[maphash.String(shardSeed, id)%uint64(len(w.shards))]
+}
+
+// add remembers id and reports whether it is new. Duplicates are counted.
+// Records without an ID, from older clients, are always new.
+func (w *dedupWindow) add(id string, now time.Time) bool {
+	if w == nil || id == "" {
+		return true
+	}
+	s := w.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.size > 0 && now.Sub(s.ring[s.head].at) > w.ttl {
+		s.pop()
+	}
+	if _, ok := s.seen[id]; ok {
+		w.dropped.Add(1)
+		return false
+	}
+	// Only a new ID evicts the oldest, so a retry of the oldest is still caught
+	if s.size == len(s.ring) {
+		s.pop()
+	}
+	s.ring[(s.head+s.size)%len(s.ring)] = dedupSlot{id: id, seq: s.seq, at: now}
+	s.seen[id] = s.seq
+	s.seq++
+	s.size++
+	return true
+}
+
+// forget removes id, so an event that failed to store can be retried.
+func (w *dedupWindow) forget(id string) {
+	if w == nil || id == "" {
+		return
+	}
+	s := w.shard(id)
+	s.mu.Lock()
+	delete(s.seen, id)
+	s.mu.Unlock()
+}
+
+// duplicates returns how many duplicate events have been dropped.
+func (w *dedupWindow) duplicates() int64 {
+	if w == nil {
+		return 0
+	}
+	return w.dropped.Load()
+}
+
+// pop expires the oldest slot. The caller holds s.mu.
+func (s *dedupShard) pop() {
+	slot := s.ring[s.head]
+	// The ID may have been forgotten and added again in a newer slot
+	if seq, ok := s.seen[slot.id]; ok && seq == slot.seq {
+		delete(s.seen, slot.id)
+	}
+	s.ring[s.head] = dedupSlot{}
+	s.head = (s.head + 1) % len(s.ring)
+	s.size--
+}
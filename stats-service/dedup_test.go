@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDedupWindowAdd(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name string
+		size int
+		ttl  time.Duration
+		adds []string        // IDs added in order, one per millisecond
+		want []bool          // add's result for each
+		at   map[int]float64 // seconds after start to add at, by index, overriding the default
+	}{
+		{
+			name: "retry is a duplicate",
+			size: 100, ttl: time.Minute,
+			adds: []string{"a", "b", "a"},
+			want: []bool{true, true, false},
+		},
+		{
+			name: "empty ID is always new",
+			size: 100, ttl: time.Minute,
+			adds: []string{"", ""},
+			want: []bool{true, true},
+		},
+		{
+			name: "retry of the oldest ID at capacity",
+			size: 1, ttl: time.Minute,
+			adds: []string{"a", "a", "a"},
+			want: []bool{true, false, false},
+		},
+		{
+			name: "new ID at capacity evicts the oldest",
+			size: 1, ttl: time.Minute,
+			adds: []string{"a", "b", "a", "a"},
+			want: []bool{true, true, true, false},
+		},
+		{
+			name: "retry after the window is new",
+			size: 100, ttl: time.Minute,
+			adds: []string{"a", "a"},
+			want: []bool{true, true},
+			at:   map[int]float64{1: 61},
+		},
+		{
+			name: "retry within the window",
+			size: 100, ttl: time.Minute,
+			adds: []string{"a", "a"},
+			want: []bool{true, false},
+			at:   map[int]float64{1: 59},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newDedupWindow(tt.size, tt.ttl)
+			var dups int64
+			for i, id := range tt.adds {
+				at := start.Add(time.Duration(i) * time.Millisecond)
+				if sec, ok := tt.at[i]; ok {
+					at = start.Add(time.Duration(sec * float64(time.Second)))
+				}
+				if got := w.add(id, at); got != tt.want[i] {
+					t.Errorf("add #%d (%q) = %v, want %v", i, id, got, tt.want[i])
+				}
+				if !tt.want[i] {
+					dups++
+				}
+			}
+			if got := w.duplicates(); got != dups {
+				t.Errorf("duplicates() = %d, want %d", got, dups)
+			}
+		})
+	}
+}
+
+func TestDedupWindowCapacity(t *testing.T) {
+	for _, size := range []int{1, 5, 31, 32, 33, 63, 100, 1000} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			w := newDedupWindow(size, time.Hour)
+			total := 0
+			for i := range w.shards {
+				total += len(w.shards[i].ring)
+				if len(w.shards[i].ring) == 0 {
+					t.Fatalf("shard %d holds no IDs", i)
+				}
+			}
+			if total != size {
+				t.Fatalf("shards hold %d IDs, want %d", total, size)
+			}
+
+			// Fill every shard exactly, then retry every ID: all are caught
+			now := time.Now()
+			var ids []string
+			held := make(map[*dedupShard]int)
+			for i := 0; len(ids) < size; i++ {
+				id := fmt.Sprintf("id-%d", i)
+				if s := w.shard(id); held[s] < len(s.ring) {
+					held[s]++
+					ids = append(ids, id)
+				}
+			}
+			for _, id := range ids {
+				if !w.add(id, now) {
+					t.Fatalf("first add of %q was a duplicate", id)
+				}
+			}
+			for _, id := range ids {
+				if w.add(id, now) {
+					t.Errorf("retry of %q was counted again", id)
+				}
+			}
+		})
+	}
+}
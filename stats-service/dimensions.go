@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dimension names accepted by StatsRequest.group_by and StatsRequest.filters.
+const (
+	dimN         = "n"
+	dimCache     = "cache"
+	dimAlgorithm = "algorithm"
+	dimStatus    = "status"
+	dimInstance  = "instance"
+	dimClient    = "client"
+)
+
+// dimensions lists every dimension in a stable order.
+var dimensions = []string{dimN, dimCache, dimAlgorithm, dimStatus, dimInstance, dimClient}
+
+// Key identifies one aggregate: a value of 'n' together with every
+// dimension the Fibonacci service reports about the request.
+type Key struct {
+	N         int    `json:"n"`
+	Cache     string `json:"cache,omitempty"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Client    string `json:"client,omitempty"`
+}
+
+// get returns the value of dimension dim as a string.
+func (k Key) get(dim string) string {
+	switch dim {
+	case dimN:
+		return strconv.Itoa(k.N)
+	case dimCache:
+		return k.Cache
+	case dimAlgorithm:
+		return k.Algorithm
+	case dimStatus:
+		return k.Status
+	case dimInstance:
+		return k.Instance
+	case dimClient:
+		return k.Client
+	}
+	return ""
+}
+
+// project keeps only the dimensions in dims and clears the rest, so keys
+// that differ only in other dimensions collapse into one group.
+func (k Key) project(dims []string) Key {
+	var p Key
+	for _, dim := range dims {
+		switch dim {
+		case dimN:
+			p.N = k.N
+		case dimCache:
+			p.Cache = k.Cache
+		case dimAlgorithm:
+			p.Algorithm = k.Algorithm
+		case dimStatus:
+			p.Status = k.Status
+		case dimInstance:
+			p.Instance = k.Instance
+		case dimClient:
+			p.Client = k.Client
+		}
+	}
+	return p
+}
+
+// matches reports whether every filtered dimension has the given value.
+func (k Key) matches(filters map[string]string) bool {
+	for dim, want := range filters {
+		if k.get(dim) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// labels returns the value of each dimension in dims.
+func (k Key) labels(dims []string) map[string]string {
+	out := make(map[string]string, len(dims))
+	for _, dim := range dims {
+		out[dim] = k.get(dim)
+	}
+	return out
+}
+
+// less orders keys by 'n' first, then by the remaining dimensions.
+func (k Key) less(o Key) bool {
+	if k.N != o.N {
+		return k.N < o.N
+	}
+	for _, dim := range dimensions[1:] {
+		if a, b := k.get(dim), o.get(dim); a != b {
+			return a < b
+		}
+	}
+	return false
+}
+
+// encode returns the key's stable storage form.
+func (k Key) encode() []byte {
+	raw, _ := json.Marshal(k)
+	return raw
+}
+
+// legacyEncode returns the bare-'n' form a key was stored under before
+// dimensions existed, if it has no other dimensions set.
+func (k Key) legacyEncode() ([]byte, bool) {
+	if k != (Key{N: k.N}) {
+		return nil, false
+	}
+	return []byte(strconv.Itoa(k.N)), true
+}
+
+// decodeKey parses a stored key. Keys written before dimensions existed are a
+// bare decimal 'n' and decode to a Key with only N set.
+func decodeKey(raw []byte) (Key, error) {
+	if n, err := strconv.Atoi(string(raw)); err == nil {
+		return Key{N: n}, nil
+	}
+	var k Key
+	if err := json.Unmarshal(raw, &k); err != nil {
+		return Key{}, fmt.Errorf("invalid key %q: %w", raw, err)
+	}
+	return k, nil
+}
+
+// validateDimensions checks group_by and filter names against the known dimensions.
+func validateDimensions(groupBy []string, filters map[string]string) error {
+	known := make(map[string]bool, len(dimensions))
+	for _, d := range dimensions {
+		known[d] = true
+	}
+	for _, d := range groupBy {
+		if !known[d] {
+			return fmt.Errorf("unknown group_by dimension %q (want one of %s)", d, strings.Join(dimensions, ", "))
+		}
+	}
+	for d := range filters {
+		if !known[d] {
+			return fmt.Errorf("unknown filter dimension %q (want one of %s)", d, strings.Join(dimensions, ", "))
+		}
+	}
+	return nil
+}
+
+// groupEntries filters entries and merges them by their projection onto groupBy.
+func groupEntries(entries map[Key]*Entry, groupBy []string, filters map[string]string) map[Key]*Entry {
+	out := make(map[Key]*Entry)
+	for k, e := range entries {
+		if !k.matches(filters) {
+			continue
+		}
+		g := k.project(groupBy)
+		if agg, ok := out[g]; ok {
+			agg.Merge(e)
+		} else {
+			out[g] = e.Clone()
+		}
+	}
+	return out
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// exportChunkSize is the payload size of each StatsChunk sent by ExportStats.
+	exportChunkSize = 32 << 10
+
+	// maxImportBytes bounds the size of a single import.
+	maxImportBytes = 64 << 20
+)
+
+// csvHeader lists the CSV columns. average_ms is derived for spreadsheets and
+// ignored on import; columns are matched by name, so their order may change.
+var csvHeader = []string{
+	"n", "cache", "algorithm", "status", "instance", "client",
+	"count", "errors", "total_time_ns", "min_ns", "max_ns", "result_bytes",
+	"average_ms", "latency_buckets",
+}
+
+// chunkWriter sends everything written to it as StatsChunk messages.
+type chunkWriter struct {
+	stream grpc.ServerStreamingServer[pb.StatsChunk]
+}
+
+// Write sends p in chunks of at most exportChunkSize bytes.
+func (w chunkWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += exportChunkSize {
+		chunk := p[i:min(i+exportChunkSize, len(p))]
+		if err := w.stream.Send(&pb.StatsChunk{Data: chunk}); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// ExportStats streams every aggregate, of every replica, in the requested format.
+func (a *statsAdminServer) ExportStats(in *pb.ExportStatsRequest, stream grpc.ServerStreamingServer[pb.StatsChunk]) error {
+	entries, err := a.stats.entries()
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "loading stats: %v", err)
+	}
+	keys := make([]Key, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+	records := make([]*pb.StatsRecord, len(keys))
+	for i, k := range keys {
+		records[i] = statsRecord(k, entries[k])
+	}
+
+	w := bufio.NewWriterSize(chunkWriter{stream}, exportChunkSize)
+	switch in.GetFormat() {
+	case pb.StatsFormat_STATS_FORMAT_NDJSON:
+		for _, r := range records {
+			raw, err := protojson.Marshal(r)
+			if err != nil {
+				return err
+			}
+			w.Write(raw)
+			w.WriteByte('\n')
+		}
+	case pb.StatsFormat_STATS_FORMAT_CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		for _, r := range records {
+			cw.Write(csvRow(r))
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	case pb.StatsFormat_STATS_FORMAT_JSON:
+		raw, err := protojson.Marshal(&pb.StatsDump{ExportedAtMs: time.Now().UnixMilli(), Records: records})
+		if err != nil {
+			return err
+		}
+		w.Write(raw)
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown format %v", in.GetFormat())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	log.Printf("Exported %d stats records as %v", len(records), in.GetFormat())
+	return nil
+}
+
+// ImportStats reads an export streamed in chunks and merges it into, or
+// replaces, the stored aggregates.
+func (a *statsAdminServer) ImportStats(stream grpc.ClientStreamingServer[pb.ImportStatsRequest, pb.ImportStatsResponse]) error {
+	var first *pb.ImportStatsRequest
+	var buf bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			first = req
+		}
+		if buf.Len()+len(req.GetData()) > maxImportBytes {
+			return status.Errorf(codes.ResourceExhausted, "import larger than %d bytes", maxImportBytes)
+		}
+		buf.Write(req.GetData())
+	}
+	if first == nil {
+		// Nothing sent, not even a format: an empty merge
+		log.Printf("Imported 0 stats records (empty import)")
+		return stream.SendAndClose(&pb.ImportStatsResponse{})
+	}
+	if first.GetMode() == pb.ImportMode_IMPORT_MODE_REPLACE {
+		if err := a.stats.checkUnreplicated("replacing stats"); err != nil {
+			return err
+		}
+	}
+
+	records, err := parseRecords(first.GetFormat(), buf.Bytes())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	imported := make(map[Key]*Entry, len(records))
+	for i, r := range records {
+		k, e, err := entryFromRecord(r)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "record %d: %v", i+1, err)
+		}
+		if agg, ok := imported[k]; ok {
+			agg.Merge(e)
+		} else {
+			imported[k] = e
+		}
+	}
+
+	res := &pb.ImportStatsResponse{}
+	if first.GetMode() == pb.ImportMode_IMPORT_MODE_REPLACE {
+		deleted, err := a.deleteKeys(func(Key) bool { return true })
+		if err != nil {
+			return err
+		}
+		res.Deleted = deleted.GetDeleted()
+	}
+	for k, e := range imported {
+		if err := a.stats.store.Merge(k, e); err != nil {
+			return status.Errorf(codes.Unavailable, "importing stats for n=%d: %v", k.N, err)
+		}
+		res.Imported++
+		res.ImportedRequests += int32(e.Count)
+	}
+
+	// Track the hottest keys of the new contents
+	entries, err := a.stats.store.Entries()
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "loading stats: %v", err)
+	}
+	a.stats.limiter.reset()
+	for _, k := range a.stats.limiter.seed(entries) {
+		a.stats.overflow(k)
+	}
+
+	log.Printf("Imported %d stats records (%v, %v), deleted %d", res.Imported, first.GetFormat(), first.GetMode(), res.Deleted)
+	return stream.SendAndClose(res)
+}
+
+// parseRecords decodes an export in the given format.
+// An empty import, such as an export of no stats, has no records.
+func parseRecords(format pb.StatsFormat, data []byte) ([]*pb.StatsRecord, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var records []*pb.StatsRecord
+	switch format {
+	case pb.StatsFormat_STATS_FORMAT_NDJSON:
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			r := &pb.StatsRecord{}
+			if err := protojson.Unmarshal(line, r); err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+			records = append(records, r)
+		}
+	case pb.StatsFormat_STATS_FORMAT_CSV:
+		rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		columns := make(map[string]int, len(rows[0]))
+		for i, name := range rows[0] {
+			columns[strings.TrimSpace(name)] = i
+		}
+		for i, row := range rows[1:] {
+			r, err := parseCSVRow(columns, row)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %v", i+2, err)
+			}
+			records = append(records, r)
+		}
+	case pb.StatsFormat_STATS_FORMAT_JSON:
+		dump := &pb.StatsDump{}
+		if err := protojson.Unmarshal(data, dump); err != nil {
+			return nil, err
+		}
+		records = dump.GetRecords()
+	default:
+		return nil, fmt.Errorf("unknown format %v", format)
+	}
+	return records, nil
+}
+
+// statsRecord converts an aggregate into its export form.
+func statsRecord(k Key, e *Entry) *pb.StatsRecord {
+	r := &pb.StatsRecord{
+		N:              int32(k.N),
+		Cache:          k.Cache,
+		Algorithm:      k.Algorithm,
+		Status:         k.Status,
+		Instance:       k.Instance,
+		Client:         k.Client,
+		Count:          e.Count,
+		Errors:         e.Errors,
+		TotalTimeNs:    int64(e.TotalTime),
+		MinNs:          int64(e.Min),
+		MaxNs:          int64(e.Max),
+		ResultBytes:    e.ResultBytes,
+		LatencyBuckets: make(map[int32]int64, len(e.Latency.Counts)),
+	}
+	for idx, c := range e.Latency.Counts {
+		r.LatencyBuckets[int32(idx)] = c
+	}
+	return r
+}
+
+// entryFromRecord converts an imported record back into an aggregate.
+// Records that couldn't come from recorded requests are rejected.
+func entryFromRecord(r *pb.StatsRecord) (Key, *Entry, error) {
+	if r.GetCount() <= 0 || r.GetErrors() < 0 || r.GetErrors() > r.GetCount() {
+		return Key{}, nil, fmt.Errorf("invalid count %d with %d errors", r.GetCount(), r.GetErrors())
+	}
+	if r.GetMinNs() < 0 || r.GetMinNs() > r.GetMaxNs() {
+		return Key{}, nil, fmt.Errorf("invalid min %dns and max %dns", r.GetMinNs(), r.GetMaxNs())
+	}
+	k := Key{
+		N:         int(r.GetN()),
+		Cache:     r.GetCache(),
+		Algorithm: r.GetAlgorithm(),
+		Status:    r.GetStatus(),
+		Instance:  r.GetInstance(),
+		Client:    r.GetClient(),
+	}
+	e := &Entry{
+		Count:       r.GetCount(),
+		Errors:      r.GetErrors(),
+		TotalTime:   time.Duration(r.GetTotalTimeNs()),
+		Min:         time.Duration(r.GetMinNs()),
+		Max:         time.Duration(r.GetMaxNs()),
+		ResultBytes: r.GetResultBytes(),
+	}
+	var observed int64
+	for idx, c := range r.GetLatencyBuckets() {
+		if idx < 0 || int(idx) > maxBucketIndex || c < 0 {
+			return Key{}, nil, fmt.Errorf("invalid latency bucket %d:%d", idx, c)
+		}
+		if observed += c; observed > e.Count {
+			return Key{}, nil, fmt.Errorf("latency buckets hold more than the %d requests", e.Count)
+		}
+		if e.Latency.Counts == nil {
+			e.Latency.Counts = make(map[int]int64)
+		}
+		e.Latency.Counts[int(idx)] += c
+	}
+	return k, e, nil
+}
+
+// csvRow formats a record as a row matching csvHeader. Latency buckets are
+// written as space-separated index:count pairs.
+func csvRow(r *pb.StatsRecord) []string {
+	idxs := make([]int, 0, len(r.GetLatencyBuckets()))
+	for idx := range r.GetLatencyBuckets() {
+		idxs = append(idxs, int(idx))
+	}
+	sort.Ints(idxs)
+	buckets := make([]string, len(idxs))
+	for i, idx := range idxs {
+		buckets[i] = fmt.Sprintf("%d:%d", idx, r.GetLatencyBuckets()[int32(idx)])
+	}
+	var avg float64
+	if r.GetCount() > 0 {
+		avg = float64(r.GetTotalTimeNs()) / float64(r.GetCount()) / float64(time.Millisecond)
+	}
+	return []string{
+		strconv.Itoa(int(r.GetN())), r.GetCache(), r.GetAlgorithm(), r.GetStatus(), r.GetInstance(), r.GetClient(),
+		strconv.FormatInt(r.GetCount(), 10), strconv.FormatInt(r.GetErrors(), 10),
+		strconv.FormatInt(r.GetTotalTimeNs(), 10), strconv.FormatInt(r.GetMinNs(), 10),
+		strconv.FormatInt(r.GetMaxNs(), 10), strconv.FormatInt(r.GetResultBytes(), 10),
+		strconv.FormatFloat(avg, 'f', -1, 64), strings.Join(buckets, " "),
+	}
+}
+
+// parseCSVRow reads a record from a row, looking columns up by header name.
+// Missing columns are left at their zero value.
+func parseCSVRow(columns map[string]int, row []string) (*pb.StatsRecord, error) {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	ints := make(map[string]int64)
+	for _, name := range []string{"n", "count", "errors", "total_time_ns", "min_ns", "max_ns", "result_bytes"} {
+		v := get(name)
+		if v == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, v)
+		}
+		ints[name] = i
+	}
+	r := &pb.StatsRecord{
+		N:           int32(ints["n"]),
+		Cache:       get("cache"),
+		Algorithm:   get("algorithm"),
+		Status:      get("status"),
+		Instance:    get("instance"),
+		Client:      get("client"),
+		Count:       ints["count"],
+		Errors:      ints["errors"],
+		TotalTimeNs: ints["total_time_ns"],
+		MinNs:       ints["min_ns"],
+		MaxNs:       ints["max_ns"],
+		ResultBytes: ints["result_bytes"],
+	}
+	if buckets := get("latency_buckets"); buckets != "" {
+		r.LatencyBuckets = make(map[int32]int64)
+		for _, pair := range strings.Fields(buckets) {
+			idxStr, countStr, ok := strings.Cut(pair, ":")
+			idx, idxErr := strconv.ParseInt(idxStr, 10, 32)
+			count, countErr := strconv.ParseInt(countStr, 10, 64)
+			if !ok || idxErr != nil || countErr != nil {
+				return nil, fmt.Errorf("invalid latency bucket %q", pair)
+			}
+			r.LatencyBuckets[int32(idx)] += count
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportStream collects the chunks sent by ExportStats.
+type exportStream struct {
+	grpc.ServerStream
+	data []byte
+}
+
+func (s *exportStream) Send(c *pb.StatsChunk) error {
+	s.data = append(s.data, c.GetData()...)
+	return nil
+}
+
+// importStream feeds chunks to ImportStats and keeps its response.
+type importStream struct {
+	grpc.ServerStream
+	reqs []*pb.ImportStatsRequest
+	res  *pb.ImportStatsResponse
+}
+
+func (s *importStream) Recv() (*pb.ImportStatsRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *importStream) SendAndClose(res *pb.ImportStatsResponse) error {
+	s.res = res
+	return nil
+}
+
+// importInto imports data into a's stats, replacing them.
+func importInto(a *statsAdminServer, format pb.StatsFormat, data string) error {
+	stream := &importStream{reqs: []*pb.ImportStatsRequest{{Format: format, Mode: pb.ImportMode_IMPORT_MODE_REPLACE, Data: []byte(data)}}}
+	return a.ImportStats(stream)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	events := []Event{
+		{Key: Key{N: 10, Cache: "hit", Algorithm: "cache", Status: "OK", Instance: "fib-1", Client: "web"}, Duration: 300 * time.Microsecond, ResultSize: 8},
+		{Key: Key{N: 10, Cache: "hit", Algorithm: "cache", Status: "OK", Instance: "fib-1", Client: "web"}, Duration: 5 * time.Millisecond, ResultSize: 8, Weight: 4},
+		{Key: Key{N: 92, Cache: "miss", Algorithm: "iterative", Status: "OK", Instance: "fib-2"}, Duration: 2 * time.Second, ResultSize: 8},
+		{Key: Key{N: 93, Status: "InvalidArgument"}, Duration: time.Microsecond},
+	}
+	formats := []pb.StatsFormat{pb.StatsFormat_STATS_FORMAT_NDJSON, pb.StatsFormat_STATS_FORMAT_CSV, pb.StatsFormat_STATS_FORMAT_JSON}
+	for _, format := range formats {
+		for _, empty := range []bool{false, true} {
+			name := format.String()
+			if empty {
+				name += " empty"
+			}
+			t.Run(name, func(t *testing.T) {
+				src := &statsAdminServer{stats: &statsService{store: NewMemoryStore()}}
+				if !empty {
+					for _, ev := range events {
+						if err := src.stats.store.Record(ev); err != nil {
+							t.Fatal(err)
+						}
+					}
+				}
+				out := &exportStream{}
+				if err := src.ExportStats(&pb.ExportStatsRequest{Format: format}, out); err != nil {
+					t.Fatalf("ExportStats: %v", err)
+				}
+
+				dst := &statsAdminServer{stats: &statsService{store: NewMemoryStore()}}
+				if err := importInto(dst, format, string(out.data)); err != nil {
+					t.Fatalf("ImportStats: %v", err)
+				}
+				want, _ := src.stats.store.Entries()
+				got, _ := dst.stats.store.Entries()
+				if len(got) != len(want) {
+					t.Fatalf("imported %d aggregates, want %d", len(got), len(want))
+				}
+				for k, w := range want {
+					wantJSON, _ := json.Marshal(w)
+					gotJSON, _ := json.Marshal(got[k])
+					if string(gotJSON) != string(wantJSON) {
+						t.Errorf("aggregate %+v = %s, want %s", k, gotJSON, wantJSON)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestImportRejectsInvalidRecords(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "zero count", csv: "n,count\n7,0\n"},
+		{name: "negative count", csv: "n,count\n7,-1\n"},
+		{name: "more errors than requests", csv: "n,count,errors\n7,1,2\n"},
+		{name: "min above max", csv: "n,count,min_ns,max_ns\n7,1,20,10\n"},
+		{name: "negative min", csv: "n,count,min_ns,max_ns\n7,1,-5,10\n"},
+		{name: "bucket index out of range", csv: "n,count,latency_buckets\n7,1,100000:1\n"},
+		{name: "negative bucket index", csv: "n,count,latency_buckets\n7,1,-1:1\n"},
+		{name: "buckets above count", csv: "n,count,latency_buckets\n7,2,64:2 65:1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &statsAdminServer{stats: &statsService{store: NewMemoryStore()}}
+			err := importInto(a, pb.StatsFormat_STATS_FORMAT_CSV, tt.csv)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("ImportStats error = %v, want InvalidArgument", err)
+			}
+			if entries, _ := a.stats.store.Entries(); len(entries) != 0 {
+				t.Errorf("invalid import stored %d aggregates", len(entries))
+			}
+		})
+	}
+}
+
+func TestFibonacciStatWithoutRequests(t *testing.T) {
+	stat := fibonacciStat(7, &Entry{})
+	if _, err := json.Marshal(stat); err != nil {
+		t.Fatalf("stat for an empty aggregate doesn't encode: %v", err)
+	}
+	if stat.AverageTimeMs != 0 || stat.AverageResultBytes != 0 {
+		t.Errorf("averages = %v ms, %v bytes, want 0", stat.AverageTimeMs, stat.AverageResultBytes)
+	}
+}
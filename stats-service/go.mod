@@ -2,15 +2,21 @@ module stats-service
 
 go 1.25.4
 
-require fibonacci-grpc/proto v0.0.0
+require (
+	fibonacci-grpc/proto v0.0.0
+	github.com/redis/go-redis/v9 v9.16.0
+	go.etcd.io/bbolt v1.4.3
+	google.golang.org/grpc v1.76.0
+	google.golang.org/protobuf v1.36.6
+)
 
 require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	golang.org/x/net v0.42.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
-	google.golang.org/grpc v1.76.0 // indirect
-	google.golang.org/protobuf v1.36.6 // indirect
 )
 
-replace fibonacci-grpc/proto => ./proto
\ No newline at end of file
+replace fibonacci-grpc/proto => ./proto
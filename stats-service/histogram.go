@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// subBucketBits sets the histogram resolution: every power-of-two range of
+// durations is split into 2^subBucketBits linear buckets, which bounds the
+// relative error of any reported percentile to about 3%.
+const subBucketBits = 5
+
+// Histogram is a sparse log-linear latency histogram.
+// Buckets are keyed by index; durations below 2^(subBucketBits+1) ns are exact.
+// Histograms merge by adding bucket counts.
+type Histogram struct {
+	Counts map[int]int64 `json:"counts"`
+}
+
+// maxBucketIndex is the bucket holding the longest duration.
+var maxBucketIndex = bucketIndex(math.MaxInt64)
+
+// bucketIndex returns the bucket holding a duration of v nanoseconds.
+func bucketIndex(v uint64) int {
+	shift := max(bits.Len64(v)-subBucketBits-1, 0)
+	return shift<<subBucketBits + int(v>>shift)
+}
+
+// bucketBounds returns the inclusive range of nanosecond values in bucket idx.
+func bucketBounds(idx int) (lo, hi uint64) {
+	shift := 0
+	if idx >= 2<<subBucketBits {
+		shift = idx>>subBucketBits - 1
+	}
+	sub := uint64(idx - shift<<subBucketBits)
+	return sub << shift, (sub+1)<<shift - 1
+}
+
+// ObserveN adds a duration seen n times.
+func (h *Histogram) ObserveN(d time.Duration, n int64) {
+	if h.Counts == nil {
+		h.Counts = make(map[int]int64)
+	}
+	h.Counts[bucketIndex(uint64(max(d, 0)))] += n
+}
+
+// Merge adds every bucket of o into h.
+func (h *Histogram) Merge(o *Histogram) {
+	if o == nil || len(o.Counts) == 0 {
+		return
+	}
+	if h.Counts == nil {
+		h.Counts = make(map[int]int64, len(o.Counts))
+	}
+	for idx, c := range o.Counts {
+		h.Counts[idx] += c
+	}
+}
+
+// Clone returns a deep copy of h.
+func (h *Histogram) Clone() *Histogram {
+	c := &Histogram{Counts: make(map[int]int64, len(h.Counts))}
+	for idx, n := range h.Counts {
+		c.Counts[idx] = n
+	}
+	return c
+}
+
+// Quantiles returns the value at each quantile q in qs (0 < q <= 1), as the
+// upper bound of the bucket that contains it. qs must be ascending.
+func (h *Histogram) Quantiles(qs ...float64) []time.Duration {
+	out := make([]time.Duration, len(qs))
+	if h == nil || len(h.Counts) == 0 {
+		return out
+	}
+
+	idxs := make([]int, 0, len(h.Counts))
+	var total int64
+	for idx, c := range h.Counts {
+		idxs = append(idxs, idx)
+		total += c
+	}
+	sort.Ints(idxs)
+
+	var seen int64
+	qi := 0
+	for _, idx := range idxs {
+		seen += h.Counts[idx]
+		_, hi := bucketBounds(idx)
+		for qi < len(qs) && seen >= int64(math.Ceil(qs[qi]*float64(total))) {
+			out[qi] = time.Duration(hi)
+			qi++
+		}
+	}
+	for ; qi < len(qs); qi++ {
+		_, hi := bucketBounds(idxs[len(idxs)-1])
+		out[qi] = time.Duration(hi)
+	}
+	return out
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"container/heap"
+	"hash/maphash"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Count-Min Sketch dimensions: 4 rows of 2048 counters (64 KiB) overestimate a
+// key's count by at most ~0.1% of all requests with ~98% probability.
+const (
+	sketchDepth = 4
+	sketchWidth = 2048
+)
+
+// overflowKey collects requests for keys that aren't tracked exactly.
+var overflowKey = Key{N: -1}
+
+// countMinSketch estimates per-key request counts in fixed memory.
+// Estimates never undercount; hash collisions may make them overcount.
+// Counters are updated atomically, so adds never block each other.
+type countMinSketch struct {
+	seed maphash.Seed
+	rows [sketchDepth][sketchWidth]atomic.Int64
+}
+
+// add counts n more requests for k and returns its new estimate.
+func (s *countMinSketch) add(k Key, n int64) int64 {
+	h := maphash.Comparable(s.seed, k)
+	h1, h2 := uint32(h), uint32(h>>32)|1
+	est := int64(math.MaxInt64)
+	for i := range s.rows {
+		est = min(est, s.rows[i][(h1+uint32(i)*h2)%sketchWidth].Add(n))
+	}
+	return est
+}
+
+// hitter is one key tracked exactly.
+type hitter struct {
+	key       Key
+	count     atomic.Int64 // estimated requests, including uncounted
+	uncounted int64        // upper bound on requests seen before the key was tracked
+	prio      int64        // count when last placed in the heap; guarded by keyLimiter.mu
+	index     int          // position in the heap; guarded by keyLimiter.mu
+
+	// recording is read-locked while requests admitted under key are being
+	// recorded, and write-locked to retire the key, so its aggregate isn't
+	// moved away while a request still has to land in it
+	recording sync.RWMutex
+	retired   bool // guarded by recording
+}
+
+// release ends recording a request under h's key. It does nothing for a nil hitter.
+func (h *hitter) release() {
+	if h != nil {
+		h.recording.RUnlock()
+	}
+}
+
+// retire waits for requests being recorded under h's key, stops further ones
+// from being admitted under it, and then runs fn, if set, before any can be.
+func (h *hitter) retire(fn func()) {
+	h.recording.Lock()
+	defer h.recording.Unlock()
+	h.retired = true
+	if fn != nil {
+		fn()
+	}
+}
+
+// hitterHeap is a min-heap of tracked keys by prio.
+type hitterHeap []*hitter
+
+func (h hitterHeap) Len() int           { return len(h) }
+func (h hitterHeap) Less(i, j int) bool { return h[i].prio < h[j].prio }
+func (h hitterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *hitterHeap) Push(x any) {
+	x.(*hitter).index = len(*h)
+	*h = append(*h, x.(*hitter))
+}
+func (h *hitterHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// keyLimiter caps how many keys are tracked exactly, so clients sweeping many
+// values of 'n' can't grow stats memory without bound. Once full, a new key
+// only displaces the coldest tracked key when the sketch estimates it has been
+// requested more often; everything else is recorded under overflowKey.
+// A nil limiter tracks every key.
+//
+// Requests for tracked keys, the common case, only touch atomics and the
+// tracked map; mu is taken when the set of tracked keys may change.
+type keyLimiter struct {
+	max        int
+	sketch     atomic.Pointer[countMinSketch]
+	tracked    sync.Map // Key -> *hitter
+	overflowed atomic.Bool
+
+	mu   sync.Mutex
+	heap hitterHeap
+}
+
+// newKeyLimiter returns a limiter tracking at most max keys, or nil if max is not positive.
+func newKeyLimiter(max int) *keyLimiter {
+	if max <= 0 {
+		return nil
+	}
+	l := &keyLimiter{max: max}
+	l.sketch.Store(&countMinSketch{seed: maphash.MakeSeed()})
+	return l
+}
+
+// admit counts n requests for k and returns the key to record them under.
+// If that key is tracked its hitter is returned too, and must be released
+// once the requests are recorded. If a tracked key was displaced it is
+// returned as well, and must be retired while moving its aggregate into
+// overflowKey.
+func (l *keyLimiter) admit(k Key, n int64) (key Key, pin, evicted *hitter) {
+	if l == nil {
+		return k, nil, nil
+	}
+	est := l.sketch.Load().add(k, n)
+	if v, ok := l.tracked.Load(k); ok {
+		h := v.(*hitter)
+		h.recording.RLock()
+		if !h.retired {
+			h.count.Add(n)
+			return k, h, nil
+		}
+		// Evicted since it was looked up, so it's no longer tracked
+		h.recording.RUnlock()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Hitters are retired only once removed from tracked, which happens under l.mu
+	if v, ok := l.tracked.Load(k); ok {
+		h := v.(*hitter)
+		h.recording.RLock()
+		h.count.Add(n)
+		return k, h, nil
+	}
+	var uncounted int64
+	if l.overflowed.Load() {
+		uncounted = est - n
+	}
+	if len(l.heap) < l.max {
+		return k, l.track(k, est, uncounted), nil
+	}
+
+	l.overflowed.Store(true)
+	coldest := l.coldest()
+	if est <= coldest.prio {
+		return overflowKey, nil, nil
+	}
+	heap.Pop(&l.heap)
+	l.tracked.Delete(coldest.key)
+	return k, l.track(k, est, est-n), coldest
+}
+
+// coldest returns the tracked key with the lowest count. Counts only grow
+// and heap priorities lag behind them, so the root is refreshed until its
+// priority is current, at which point it is the true minimum.
+// The caller holds l.mu.
+func (l *keyLimiter) coldest() *hitter {
+	for {
+		h := l.heap[0]
+		count := h.count.Load()
+		if count == h.prio {
+			return h
+		}
+		h.prio = count
+		heap.Fix(&l.heap, 0)
+	}
+}
+
+// track starts tracking k exactly and returns its hitter, read-locked for
+// recording. The caller holds l.mu.
+func (l *keyLimiter) track(k Key, count, uncounted int64) *hitter {
+	h := &hitter{key: k, uncounted: uncounted, prio: count}
+	h.count.Store(count)
+	h.recording.RLock()
+	heap.Push(&l.heap, h)
+	l.tracked.Store(k, h)
+	return h
+}
+
+// seed tracks the hottest of the stored aggregates, e.g. after a restart with
+// a persistent store, and returns the keys that don't fit so their aggregates
+// can be moved into overflowKey.
+func (l *keyLimiter) seed(entries map[Key]*Entry) []Key {
+	if l == nil {
+		return nil
+	}
+	keys := make([]Key, 0, len(entries))
+	for k := range entries {
+		if k != overflowKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return entries[keys[i]].Count > entries[keys[j]].Count })
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := entries[overflowKey]; ok {
+		l.overflowed.Store(true)
+	}
+	sketch := l.sketch.Load()
+	var rest []Key
+	for _, k := range keys {
+		count := entries[k].Count
+		sketch.add(k, count)
+		if len(l.heap) < l.max {
+			l.track(k, count, 0).release()
+		} else {
+			l.overflowed.Store(true)
+			rest = append(rest, k)
+		}
+	}
+	return rest
+}
+
+// forget stops tracking every key matching match.
+func (l *keyLimiter) forget(match func(Key) bool) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var matched []*hitter
+	for _, h := range l.heap {
+		if match(h.key) {
+			matched = append(matched, h)
+		}
+	}
+	for _, h := range matched {
+		heap.Remove(&l.heap, h.index)
+		l.tracked.Delete(h.key)
+		h.retire(nil)
+	}
+}
+
+// reset forgets every key and clears the sketch, so stats are exact again
+// until the limit is next reached.
+func (l *keyLimiter) reset() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sketch.Store(&countMinSketch{seed: l.sketch.Load().seed})
+	l.tracked.Clear()
+	for _, h := range l.heap {
+		h.retire(nil)
+	}
+	l.heap = nil
+	l.overflowed.Store(false)
+}
+
+// approximate reports whether any request has been recorded under overflowKey.
+func (l *keyLimiter) approximate() bool {
+	return l != nil && l.overflowed.Load()
+}
+
+// uncounted returns, per tracked key, an upper bound on the requests recorded
+// under overflowKey before the key was tracked exactly.
+func (l *keyLimiter) uncounted() map[Key]int64 {
+	out := make(map[Key]int64)
+	if l == nil {
+		return out
+	}
+	l.tracked.Range(func(k, h any) bool {
+		if u := h.(*hitter).uncounted; u > 0 {
+			out[k.(Key)] = u
+		}
+		return true
+	})
+	return out
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+func TestKeyLimiterAdmit(t *testing.T) {
+	type admission struct {
+		n       int   // key admitted
+		count   int64 // requests it stands for
+		want    int   // key to record under; -1 for overflowKey
+		evicted int   // key displaced, or 0 for none
+	}
+	tests := []struct {
+		name  string
+		max   int
+		admit []admission
+	}{
+		{
+			name: "under the limit every key is tracked",
+			max:  3,
+			admit: []admission{
+				{n: 1, count: 1, want: 1},
+				{n: 2, count: 1, want: 2},
+				{n: 3, count: 1, want: 3},
+				{n: 1, count: 1, want: 1},
+			},
+		},
+		{
+			name: "cold new key overflows",
+			max:  2,
+			admit: []admission{
+				{n: 1, count: 5, want: 1},
+				{n: 2, count: 5, want: 2},
+				{n: 3, count: 1, want: -1},
+				{n: 1, count: 1, want: 1},
+			},
+		},
+		{
+			name: "hot new key displaces the coldest",
+			max:  2,
+			admit: []admission{
+				{n: 1, count: 5, want: 1},
+				{n: 2, count: 2, want: 2},
+				{n: 3, count: 1, want: -1},
+				{n: 3, count: 1, want: -1},
+				{n: 3, count: 1, want: 3, evicted: 2},
+				{n: 2, count: 1, want: -1},
+			},
+		},
+		{
+			name: "coldest is found after tracked counts grew",
+			max:  2,
+			admit: []admission{
+				{n: 1, count: 1, want: 1},
+				{n: 2, count: 2, want: 2},
+				{n: 1, count: 9, want: 1},
+				{n: 3, count: 3, want: 3, evicted: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newKeyLimiter(tt.max)
+			for i, a := range tt.admit {
+				key, pin, evicted := l.admit(Key{N: a.n}, a.count)
+				pin.release()
+				if want := (Key{N: a.want}); key != want {
+					t.Errorf("admit #%d (n=%d) recorded under %v, want %v", i, a.n, key, want)
+				}
+				switch {
+				case a.evicted == 0 && evicted != nil:
+					t.Errorf("admit #%d (n=%d) evicted n=%d, want none", i, a.n, evicted.key.N)
+				case a.evicted != 0 && evicted == nil:
+					t.Errorf("admit #%d (n=%d) evicted none, want n=%d", i, a.n, a.evicted)
+				case a.evicted != 0 && evicted.key.N != a.evicted:
+					t.Errorf("admit #%d (n=%d) evicted n=%d, want n=%d", i, a.n, evicted.key.N, a.evicted)
+				}
+				if evicted != nil {
+					evicted.retire(nil)
+				}
+			}
+			if got := len(l.heap); got > tt.max {
+				t.Errorf("tracking %d keys, limit %d", got, tt.max)
+			}
+		})
+	}
+}
+
+func TestKeyLimiterRetireWaitsForRecording(t *testing.T) {
+	l := newKeyLimiter(1)
+	_, pin, _ := l.admit(Key{N: 1}, 1)
+
+	// A hotter key displaces n=1 while a request for it is being recorded
+	key, newPin, evicted := l.admit(Key{N: 2}, 10)
+	newPin.release()
+	if key != (Key{N: 2}) || evicted != pin {
+		t.Fatalf("admit(n=2) = %v evicting %v, want n=2 evicting n=1", key, evicted)
+	}
+	retired := make(chan struct{})
+	go evicted.retire(func() { close(retired) })
+	select {
+	case <-retired:
+		t.Fatal("n=1 was retired while a request for it was being recorded")
+	case <-time.After(50 * time.Millisecond):
+	}
+	pin.release()
+	<-retired
+
+	if key, pin, _ := l.admit(Key{N: 1}, 1); key != overflowKey || pin != nil {
+		t.Errorf("admit(n=1) after eviction = %v, want %v", key, overflowKey)
+	}
+}
+
+// TestRecordKeepsKeysBounded records many keys concurrently, evicting keys
+// while requests for them are being recorded, and checks that no evicted key
+// is left behind in the store.
+func TestRecordKeepsKeysBounded(t *testing.T) {
+	const maxKeys, workers, perWorker = 8, 8, 5000
+	series, err := NewTimeSeries(defaultTiers, 0, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &statsService{
+		store:   NewMemoryStore(),
+		windows: NewRollingWindows(),
+		series:  series,
+		hub:     newWatchHub(),
+		limiter: newKeyLimiter(maxKeys),
+	}
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				// A few hot keys shared by all workers and a stream of
+				// rising ones that keep displacing each other
+				n := (w*perWorker + i) % 64
+				if i%2 == 0 {
+					n = i % 4
+				}
+				if err := s.record(&pb.RecordRequest{N: int32(n), Duration: 1000}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := s.store.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for k, e := range entries {
+		total += e.Count
+		if k == overflowKey {
+			continue
+		}
+		if _, ok := s.limiter.tracked.Load(k); !ok {
+			t.Errorf("n=%d is stored but not tracked", k.N)
+		}
+	}
+	if len(entries) > maxKeys+1 {
+		t.Errorf("store holds %d keys, want at most %d", len(entries), maxKeys+1)
+	}
+	if want := int64(workers * perWorker); total != want {
+		t.Errorf("store counts %d requests, want %d", total, want)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+// instanceTracker remembers when each Fibonacci instance last served a
+// request, so instances that stopped reporting can be flagged. A nil tracker
+// knows no instances.
+type instanceTracker struct {
+	started    time.Time
+	staleAfter time.Duration
+	seen       sync.Map // instance -> *atomic.Int64 holding Unix milliseconds
+}
+
+// newInstanceTracker returns a tracker flagging instances silent for longer than staleAfter.
+func newInstanceTracker(staleAfter time.Duration) *instanceTracker {
+	return &instanceTracker{started: time.Now(), staleAfter: staleAfter}
+}
+
+// observe records that instance served a request at t.
+func (t *instanceTracker) observe(instance string, at time.Time) {
+	if t == nil || instance == "" {
+		return
+	}
+	v, ok := t.seen.Load(instance)
+	if !ok {
+		v, _ = t.seen.LoadOrStore(instance, new(atomic.Int64))
+	}
+	last := v.(*atomic.Int64)
+	ms := at.UnixMilli()
+	for {
+		cur := last.Load()
+		if ms <= cur || last.CompareAndSwap(cur, ms) {
+			return
+		}
+	}
+}
+
+// lastSeen returns when every known instance last served a request, in Unix milliseconds.
+func (t *instanceTracker) lastSeen() map[string]int64 {
+	out := make(map[string]int64)
+	if t == nil {
+		return out
+	}
+	t.seen.Range(func(k, v any) bool {
+		out[k.(string)] = v.(*atomic.Int64).Load()
+		return true
+	})
+	return out
+}
+
+// reset forgets every instance.
+func (t *instanceTracker) reset() {
+	if t != nil {
+		t.seen.Clear()
+	}
+}
+
+// stale reports whether an instance last seen at lastSeenMs (zero if not
+// since startup) has stopped reporting.
+func (t *instanceTracker) stale(lastSeenMs int64, now time.Time) bool {
+	if t == nil {
+		return false
+	}
+	last := t.started
+	if lastSeenMs > 0 {
+		last = time.UnixMilli(lastSeenMs)
+	}
+	return now.Sub(last) > t.staleAfter
+}
+
+// instanceStats breaks entries down per instance, sorted by instance. Known
+// instances without requests in entries, such as ones that stopped reporting
+// before the window, are listed with zero counts. Records from clients that
+// don't report an instance are left out.
+func (s *statsService) instanceStats(entries map[Key]*Entry, span time.Duration, filters map[string]string) []*pb.InstanceStat {
+	groups := groupEntries(entries, []string{dimInstance}, filters)
+	lastSeen := s.lastSeen()
+	for instance := range lastSeen {
+		k := Key{Instance: instance}
+		if want, ok := filters[dimInstance]; ok && want != instance {
+			continue
+		}
+		if _, ok := groups[k]; !ok {
+			groups[k] = &Entry{}
+		}
+	}
+
+	now := time.Now()
+	res := make([]*pb.InstanceStat, 0, len(groups))
+	for k, e := range groups {
+		if k.Instance == "" {
+			continue
+		}
+		stat := &pb.FibonacciStat{}
+		if e.Count > 0 {
+			stat = fibonacciStat(0, e)
+			stat.Qps = rate(e.Count, span)
+		}
+		res = append(res, &pb.InstanceStat{
+			Instance:   k.Instance,
+			Stats:      stat,
+			LastSeenMs: lastSeen[k.Instance],
+			Stale:      s.instances.stale(lastSeen[k.Instance], now),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Instance < res[j].Instance })
+	return res
+}
+
+// lastSeen returns when every instance last served a request, as heard by any replica.
+func (s *statsService) lastSeen() map[string]int64 {
+	out := s.instances.lastSeen()
+	s.replicas.mergeLastSeen(out)
+	return out
+}
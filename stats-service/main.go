@@ -2,81 +2,364 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 	"net"
-	"sort"
-	"sync"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	pb "fibonacci-grpc/proto/stats"
 
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // statsService implements the Stats gRPC service.
 type statsService struct {
 	pb.UnimplementedStatsServer
-	stats *Stats
-}
-
-// Stats stores aggregated statistics for Fibonacci requests.
-type Stats struct {
-	mu            sync.Mutex
-	RequestCount  map[int]int           // Number of requests per 'n'
-	TotalRequests int                   // Total number of requests
-	TotalTime     map[int]time.Duration // Total processing time per 'n'
+	store     StatsStore
+	windows   *RollingWindows
+	series    *TimeSeries
+	hub       *watchHub
+	limiter   *keyLimiter
+	clients   *clientSet
+	dedup     *dedupWindow
+	replicas  *replicator
+	alerts    *alerter
+	instances *instanceTracker
+	slowLog   *slowLog
 }
 
 // RecordNo records a Fibonacci request and its duration.
 // This method is called by the Fibonacci service asynchronously.
 func (s *statsService) RecordNo(_ context.Context, r *pb.RecordRequest) (*pb.RecordResponse, error) {
-	s.stats.mu.Lock()
-	defer s.stats.mu.Unlock()
+	err := s.record(r)
+	if errors.Is(err, errDuplicate) {
+		log.Printf("Dropped duplicate record for n=%d, event=%s", r.GetN(), r.GetEventId())
+		return &pb.RecordResponse{Success: true, Duplicate: true}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "recording stats: %v", err)
+	}
+	// Batched and streamed records are logged per call, keeping log's lock off the hot path
+	log.Printf("Recorded request for n=%d, duration=%v", r.GetN(), time.Duration(r.GetDuration()))
+	return &pb.RecordResponse{Success: true}, nil
+}
 
-	n := int(r.GetN())
-	dur := time.Duration(r.GetDuration())
+// RecordBatch records every request in the batch. Records that fail to store
+// or were already recorded are counted rather than failing the whole batch.
+func (s *statsService) RecordBatch(_ context.Context, r *pb.RecordBatchRequest) (*pb.RecordBatchResponse, error) {
+	res := &pb.RecordBatchResponse{}
+	for _, rec := range r.GetRecords() {
+		s.tally(res, s.record(rec))
+	}
+	log.Printf("Recorded batch: %d stored, %d failed, %d duplicates", res.Recorded, res.Failed, res.Duplicates)
+	return res, nil
+}
 
-	s.stats.RequestCount[n]++
-	s.stats.TotalRequests++
-	s.stats.TotalTime[n] += dur
+// RecordStream records every request received on the stream and replies with
+// the totals once the client closes its side.
+func (s *statsService) RecordStream(stream grpc.ClientStreamingServer[pb.RecordRequest, pb.RecordBatchResponse]) error {
+	res := &pb.RecordBatchResponse{}
+	for {
+		rec, err := stream.Recv()
+		if err == io.EOF {
+			log.Printf("Recorded stream: %d stored, %d failed, %d duplicates", res.Recorded, res.Failed, res.Duplicates)
+			return stream.SendAndClose(res)
+		}
+		if err != nil {
+			return err
+		}
+		s.tally(res, s.record(rec))
+	}
+}
 
-	log.Printf("Recorded request for n=%d, duration=%v", n, dur)
-	return &pb.RecordResponse{Success: true}, nil
+// tally counts the outcome of recording one request of a batch or stream.
+func (s *statsService) tally(res *pb.RecordBatchResponse, err error) {
+	switch {
+	case err == nil:
+		res.Recorded++
+	case errors.Is(err, errDuplicate):
+		res.Duplicates++
+	default:
+		res.Failed++
+	}
+}
+
+// record stores a single request and feeds the windows, time series and watchers.
+// A retried request whose event ID was already recorded returns errDuplicate.
+func (s *statsService) record(r *pb.RecordRequest) error {
+	if !s.dedup.add(r.GetEventId(), time.Now()) {
+		return errDuplicate
+	}
+	ev := Event{
+		Key: Key{
+			N:         int(r.GetN()),
+			Cache:     cacheLabel(r.GetCacheStatus()),
+			Algorithm: r.GetAlgorithm(),
+			Status:    codes.Code(r.GetStatusCode()).String(),
+			Instance:  r.GetInstanceId(),
+			Client:    s.clients.label(r.GetClientId()),
+		},
+		Duration:   time.Duration(r.GetDuration()),
+		Time:       time.Now(),
+		ResultSize: int(r.GetResultSize()),
+		Weight:     int64(r.GetSampleWeight()),
+	}
+	if r.GetTimestampMs() != 0 {
+		ev.Time = time.UnixMilli(r.GetTimestampMs())
+	}
+	// Keys beyond STATS_MAX_KEYS are aggregated under overflowKey; watchers
+	// still see the original event.
+	tracked := ev
+	key, pin, evicted := s.limiter.admit(ev.Key, ev.weight())
+	tracked.Key = key
+	err := s.store.Record(tracked)
+	pin.release()
+	if evicted != nil {
+		evicted.retire(func() { s.overflow(evicted.key) })
+	}
+	if err != nil {
+		log.Printf("Failed to record request for n=%d: %v", ev.N, err)
+		s.dedup.forget(r.GetEventId())
+		return err
+	}
+	s.windows.Record(tracked)
+	s.series.Record(tracked)
+	s.instances.observe(ev.Instance, ev.Time)
+	s.slowLog.add(r, ev.Time)
+	s.hub.publish(ev)
+	return nil
+}
+
+// overflow moves the stored aggregate for k, which is no longer tracked
+// exactly, into overflowKey.
+func (s *statsService) overflow(k Key) {
+	e, err := s.store.Delete(k)
+	if err != nil {
+		log.Printf("Failed to evict stats for n=%d: %v", k.N, err)
+		return
+	}
+	if e == nil {
+		return
+	}
+	if err := s.store.Merge(overflowKey, e); err != nil {
+		log.Printf("Failed to merge evicted stats for n=%d: %v", k.N, err)
+	}
 }
 
 // GetStats returns aggregated Fibonacci statistics, including request counts and average times.
-func (s *statsService) GetStats(_ context.Context, in *emptypb.Empty) (*pb.StatsResponse, error) {
+// With a window set it reports only the recent rolling window, plus request rates.
+// Records can be filtered and grouped by any dimension; by default they are grouped by 'n'.
+// Rows can be limited to a range of 'n', sorted, cut to the top K and paged.
+func (s *statsService) GetStats(_ context.Context, in *pb.StatsRequest) (*pb.StatsResponse, error) {
 	var res []*pb.FibonacciStat
 
-	s.stats.mu.Lock()
-	defer s.stats.mu.Unlock()
+	groupBy := in.GetGroupBy()
+	if len(groupBy) == 0 {
+		groupBy = []string{dimN}
+	}
+	if err := validateDimensions(groupBy, in.GetFilters()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if in.GetLimit() < 0 || in.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and page_size must be non-negative")
+	}
+
+	var entries map[Key]*Entry
+	var span time.Duration
+	var err error
+	if in.GetWindow() == "" {
+		entries, err = s.entries()
+	} else {
+		if err := s.checkUnreplicated("window"); err != nil {
+			return nil, err
+		}
+		entries, span, err = s.windows.Entries(in.GetWindow())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to load stats: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "loading stats: %v", err)
+	}
+	for k := range entries {
+		if !inRange(k, in) {
+			delete(entries, k)
+		}
+	}
+	groups := groupEntries(entries, groupBy, in.GetFilters())
+	var instances []*pb.InstanceStat
+	if in.GetIncludeInstances() {
+		instances = s.instanceStats(entries, span, in.GetFilters())
+	}
+
+	// Requests for untracked keys are counted under overflowKey; tracked keys
+	// may have had requests there before they were tracked exactly.
+	_, hasOverflow := entries[overflowKey]
+	overflowGroup := overflowKey.project(groupBy)
+	uncounted := make(map[Key]int64)
+	if in.GetWindow() == "" {
+		for k, n := range s.limiter.uncounted() {
+			if inRange(k, in) && k.matches(in.GetFilters()) {
+				uncounted[k.project(groupBy)] += n
+			}
+		}
+	}
 
 	// Collect keys and sort
-	keys := make([]int, 0, len(s.stats.RequestCount))
-	for k := range s.stats.RequestCount {
+	keys := make([]Key, 0, len(groups))
+	for k := range groups {
 		keys = append(keys, k)
 	}
-	sort.Ints(keys)
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
 
 	// Build sorted stats response
-	for _, n := range keys {
-		count := s.stats.RequestCount[n]
-		res = append(res, &pb.FibonacciStat{
-			N:             int32(n),
-			RequestCount:  int32(count),
-			AverageTimeMs: float64(s.stats.TotalTime[n].Milliseconds()) / float64(count),
-		})
+	var totalRequests, totalErrors int64
+	for _, k := range keys {
+		e := groups[k]
+		totalRequests += e.Count
+		totalErrors += e.Errors
+		stat := fibonacciStat(k.N, e)
+		stat.Qps = rate(e.Count, span)
+		stat.EstimatedCount = int32(e.Count + uncounted[k])
+		stat.Approximate = uncounted[k] > 0 || (hasOverflow && k == overflowGroup)
+		if len(in.GetGroupBy()) > 0 {
+			stat.Labels = k.labels(groupBy)
+		}
+		res = append(res, stat)
+	}
+
+	// Sort, keep the top K and select the requested page
+	if err := sortStats(res, in.GetSortBy(), in.GetDescending()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if limit := int(in.GetLimit()); limit > 0 && limit < len(res) {
+		res = res[:limit]
+	}
+	totalRows := len(res)
+	res, nextPageToken, err := paginate(res, in.GetPageToken(), int(in.GetPageSize()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	log.Printf("Returning stats: total requests=%d, tracked values=%d", s.stats.TotalRequests, len(keys))
+	log.Printf("Returning stats: window=%q, total requests=%d, errors=%d, rows=%d of %d", in.GetWindow(), totalRequests, totalErrors, len(res), totalRows)
 	return &pb.StatsResponse{
-		TotalRequests:  int32(s.stats.TotalRequests),
+		TotalRequests:  int32(totalRequests),
 		FibonacciStats: res,
+		Window:         in.GetWindow(),
+		Qps:            rate(totalRequests, span),
+		TotalErrors:    int32(totalErrors),
+		ErrorRate:      ratio(totalErrors, totalRequests),
+		NextPageToken:  nextPageToken,
+		TotalRows:      int32(totalRows),
+		Approximate:    s.limiter.approximate(),
+
+		DuplicatesDropped: s.dedup.duplicates(),
+		Replicas:          int32(s.replicas.replicas()),
+		Instances:         instances,
 	}, nil
 }
 
+// GetAlerts returns the alerts raised by the rules in ALERT_RULES_FILE.
+func (s *statsService) GetAlerts(_ context.Context, in *pb.GetAlertsRequest) (*pb.GetAlertsResponse, error) {
+	return s.alerts.list(in.GetIncludePending()), nil
+}
+
+// GetSlowLog returns the most recent computations slower than STATS_SLOWLOG_THRESHOLD.
+func (s *statsService) GetSlowLog(_ context.Context, in *pb.GetSlowLogRequest) (*pb.GetSlowLogResponse, error) {
+	if in.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be non-negative")
+	}
+	if err := s.checkUnreplicated("the slow log"); err != nil {
+		return nil, err
+	}
+	return s.slowLog.list(in), nil
+}
+
+// entries returns the all-time aggregates of every replica.
+func (s *statsService) entries() (map[Key]*Entry, error) {
+	entries, err := s.store.Entries()
+	if err != nil {
+		return nil, err
+	}
+	s.replicas.mergeInto(entries)
+	return entries, nil
+}
+
+// checkUnreplicated rejects op while replication is on. Deleting aggregates
+// could only delete this replica's records, leaving the other replicas' in the
+// global view, and the rolling windows, time series, slow log and event stream
+// are never replicated, so they would only cover this replica's requests.
+func (s *statsService) checkUnreplicated(op string) error {
+	if s.replicas != nil {
+		return status.Errorf(codes.FailedPrecondition, "%s is not supported with replication (STATS_PEERS set)", op)
+	}
+	return nil
+}
+
+// cacheLabel converts a reported cache status into its dimension value.
+func cacheLabel(c pb.CacheStatus) string {
+	switch c {
+	case pb.CacheStatus_CACHE_STATUS_HIT:
+		return "hit"
+	case pb.CacheStatus_CACHE_STATUS_MISS:
+		return "miss"
+	case pb.CacheStatus_CACHE_STATUS_BYPASS:
+		return "bypass"
+	default:
+		return ""
+	}
+}
+
+// rate returns count per second over span, or zero if span is not positive.
+func rate(count int64, span time.Duration) float64 {
+	if span <= 0 {
+		return 0
+	}
+	return float64(count) / span.Seconds()
+}
+
+// fibonacciStat converts an aggregate into its wire form. Averages are
+// computed from nanoseconds so sub-millisecond durations aren't truncated.
+func fibonacciStat(n int, e *Entry) *pb.FibonacciStat {
+	q := e.Latency.Quantiles(0.5, 0.9, 0.99, 0.999)
+	return &pb.FibonacciStat{
+		N:             int32(n),
+		RequestCount:  int32(e.Count),
+		AverageTimeMs: ratio(int64(e.TotalTime), e.Count) / float64(time.Millisecond),
+		MinUs:         micros(e.Min),
+		MaxUs:         micros(e.Max),
+		P50Us:         micros(min(q[0], e.Max)),
+		P90Us:         micros(min(q[1], e.Max)),
+		P99Us:         micros(min(q[2], e.Max)),
+		P999Us:        micros(min(q[3], e.Max)),
+
+		AverageResultBytes: ratio(e.ResultBytes, e.Count),
+		ErrorCount:         int32(e.Errors),
+		ErrorRate:          ratio(e.Errors, e.Count),
+	}
+}
+
+// ratio returns part/total, or zero when total is zero.
+func ratio(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// micros converts d to fractional microseconds.
+func micros(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
+
 // main starts the Stats gRPC server on port 5001.
 func main() {
 	port := os.Getenv("PORT")
@@ -85,14 +368,139 @@ func main() {
 		log.Fatalf("Failed to listen on :%s: %v", port, err)
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxGossipBytes),
+		grpc.UnaryInterceptor(AdminAuthInterceptor),
+		grpc.StreamInterceptor(AdminAuthStreamInterceptor),
+	)
+
+	store, err := NewStoreFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to open stats store: %v", err)
+	}
+	defer store.Close()
+
+	tsMaxSeries, err := strconv.Atoi(getenv("STATS_TS_MAX_SERIES", "100"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_TS_MAX_SERIES: %v", err)
+	}
+	tsIdle, err := time.ParseDuration(getenv("STATS_TS_IDLE", "1h"))
+	if err != nil || tsIdle <= 0 {
+		log.Fatalf("Invalid STATS_TS_IDLE: %q", os.Getenv("STATS_TS_IDLE"))
+	}
+	series, err := NewTimeSeries(getenv("STATS_TS_TIERS", defaultTiers), tsMaxSeries, tsIdle)
+	if err != nil {
+		log.Fatalf("Invalid STATS_TS_TIERS: %v", err)
+	}
+	go series.run(time.Minute)
 
-	defaultStats := &Stats{
-		RequestCount: make(map[int]int),
-		TotalTime:    make(map[int]time.Duration),
+	staleAfter, err := time.ParseDuration(getenv("STATS_INSTANCE_STALE_AFTER", "1m"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_INSTANCE_STALE_AFTER: %v", err)
 	}
+	instances := newInstanceTracker(staleAfter)
 
-	pb.RegisterStatsServer(server, &statsService{stats: defaultStats})
+	slowLogSize, err := strconv.Atoi(getenv("STATS_SLOWLOG_SIZE", "128"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_SLOWLOG_SIZE: %v", err)
+	}
+	slowLogThreshold, err := time.ParseDuration(getenv("STATS_SLOWLOG_THRESHOLD", "10ms"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_SLOWLOG_THRESHOLD: %v", err)
+	}
+
+	maxKeys, err := strconv.Atoi(getenv("STATS_MAX_KEYS", "10000"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_MAX_KEYS: %v", err)
+	}
+	maxClients, err := strconv.Atoi(getenv("STATS_MAX_CLIENTS", "100"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_MAX_CLIENTS: %v", err)
+	}
+	dedupSize, err := strconv.Atoi(getenv("STATS_DEDUP_SIZE", "100000"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_DEDUP_SIZE: %v", err)
+	}
+	dedupTTL, err := time.ParseDuration(getenv("STATS_DEDUP_WINDOW", "10m"))
+	if err != nil {
+		log.Fatalf("Invalid STATS_DEDUP_WINDOW: %v", err)
+	}
+	// Replicas gossip their own aggregates; a shared Redis store already holds everyone's
+	var replicas *replicator
+	var peers []string
+	if v := os.Getenv("STATS_PEERS"); v != "" {
+		if os.Getenv("STATS_STORE") == "redis" {
+			log.Fatalf("STATS_PEERS cannot be used with STATS_STORE=redis, which replicas already share")
+		}
+		if peerToken == "" {
+			log.Fatalf("STATS_PEERS requires STATS_PEER_TOKEN, the token replicas gossip with")
+		}
+		peers = strings.Split(v, ",")
+		id := os.Getenv("STATS_REPLICA_ID")
+		if id == "" {
+			id, _ = os.Hostname()
+		}
+		replicas = newReplicator(id, store, instances)
+	}
+	gossipInterval, err := time.ParseDuration(getenv("STATS_GOSSIP_INTERVAL", "2s"))
+	if err != nil || gossipInterval <= 0 {
+		log.Fatalf("Invalid STATS_GOSSIP_INTERVAL: %q", os.Getenv("STATS_GOSSIP_INTERVAL"))
+	}
+
+	svc := &statsService{
+		store:     replicas.wrap(store),
+		windows:   NewRollingWindows(),
+		series:    series,
+		hub:       newWatchHub(),
+		limiter:   newKeyLimiter(maxKeys),
+		clients:   newClientSet(maxClients),
+		dedup:     newDedupWindow(dedupSize, dedupTTL),
+		replicas:  replicas,
+		instances: instances,
+		slowLog:   newSlowLog(slowLogSize, slowLogThreshold),
+	}
+	// Resume tracking the hottest keys already in a persistent store
+	entries, err := store.Entries()
+	if err != nil {
+		log.Fatalf("Failed to load stats: %v", err)
+	}
+	svc.clients.seed(entries)
+	for _, k := range svc.limiter.seed(entries) {
+		svc.overflow(k)
+	}
+
+	maxSeries, err := strconv.Atoi(getenv("METRICS_MAX_SERIES", "100"))
+	if err != nil {
+		log.Fatalf("Invalid METRICS_MAX_SERIES: %v", err)
+	}
+	if path := os.Getenv("ALERT_RULES_FILE"); path != "" {
+		rules, err := loadAlertRules(path)
+		if err != nil {
+			log.Fatalf("Invalid ALERT_RULES_FILE: %v", err)
+		}
+		for _, rule := range rules {
+			if rule.Window != "" && replicas != nil {
+				log.Fatalf("Invalid ALERT_RULES_FILE: rule %q: windows are not supported with replication (STATS_PEERS set)", rule.Name)
+			}
+		}
+		interval, err := time.ParseDuration(getenv("ALERT_EVAL_INTERVAL", "15s"))
+		if err != nil || interval <= 0 {
+			log.Fatalf("Invalid ALERT_EVAL_INTERVAL: %q", os.Getenv("ALERT_EVAL_INTERVAL"))
+		}
+		svc.alerts = newAlerter(svc, rules, os.Getenv("ALERT_WEBHOOK_URL"))
+		go svc.alerts.run(interval)
+		log.Printf("Evaluating %d alert rules every %v", len(rules), interval)
+	}
+
+	go serveMetrics(getenv("METRICS_PORT", "9102"), &metricsHandler{stats: svc, maxSeries: maxSeries})
+
+	pb.RegisterStatsServer(server, svc)
+	pb.RegisterStatsAdminServer(server, &statsAdminServer{stats: svc})
+	if replicas != nil {
+		pb.RegisterStatsReplicationServer(server, replicas)
+		go replicas.run(peers, gossipInterval)
+		log.Printf("Replicating stats as %s with peers %v", replicas.id, peers)
+	}
 
 	log.Printf("Stats gRPC server running on :%s\n", port)
 	if err := server.Serve(lis); err != nil {
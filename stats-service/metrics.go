@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsBuckets are the upper bounds of the exported latency histogram buckets.
+// Histogram buckets are assigned by their upper bound, so a count may land
+// one Prometheus bucket later than the exact duration would.
+var metricsBuckets = []time.Duration{
+	time.Microsecond, 5 * time.Microsecond, 10 * time.Microsecond, 25 * time.Microsecond,
+	50 * time.Microsecond, 100 * time.Microsecond, 250 * time.Microsecond, 500 * time.Microsecond,
+	time.Millisecond, 2500 * time.Microsecond, 5 * time.Millisecond, 10 * time.Millisecond,
+	25 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond,
+	500 * time.Millisecond, time.Second,
+}
+
+// metricsOther labels the series that aggregates every 'n' beyond the series limit.
+const metricsOther = "other"
+
+// metricsHandler serves the aggregated stats in the Prometheus text exposition format.
+// At most maxSeries values of 'n' get their own series; the rest are summed under
+// n="other", so scrapes stay bounded however many 'n' are tracked. An 'n' keeps
+// its series once it has one, so no counter moves between n and "other", which
+// Prometheus would read as counter resets. Free series go to the most requested
+// unlabelled 'n' first.
+type metricsHandler struct {
+	stats     *statsService
+	maxSeries int
+
+	mu       sync.Mutex
+	labelled map[int]bool // 'n' with their own series
+}
+
+// ServeHTTP writes every metric for one scrape.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	entries, err := h.stats.store.Entries()
+	if err != nil {
+		log.Printf("Failed to load stats for metrics: %v", err)
+		http.Error(w, "loading stats: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	total, perN := h.series(entries)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	writeHeader(bw, "fibonacci_requests_total", "counter", "Fibonacci requests recorded by the stats service.")
+	fmt.Fprintf(bw, "fibonacci_requests_total %d\n", total.Count)
+	writeHeader(bw, "fibonacci_request_errors_total", "counter", "Fibonacci requests that failed with a non-OK status code.")
+	fmt.Fprintf(bw, "fibonacci_request_errors_total %d\n", total.Errors)
+
+	writeHeader(bw, "fibonacci_requests_by_n_total", "counter", "Fibonacci requests per n; less requested n are summed under n=\"other\".")
+	for _, s := range perN {
+		fmt.Fprintf(bw, "fibonacci_requests_by_n_total{n=%q} %d\n", s.label, s.entry.Count)
+	}
+	writeHeader(bw, "fibonacci_request_errors_by_n_total", "counter", "Failed Fibonacci requests per n.")
+	for _, s := range perN {
+		fmt.Fprintf(bw, "fibonacci_request_errors_by_n_total{n=%q} %d\n", s.label, s.entry.Errors)
+	}
+
+	writeHeader(bw, "fibonacci_request_duration_seconds", "histogram", "Fibonacci computation time.")
+	writeHistogram(bw, "fibonacci_request_duration_seconds", "", total)
+	writeHeader(bw, "fibonacci_request_duration_by_n_seconds", "histogram", "Fibonacci computation time per n.")
+	for _, s := range perN {
+		writeHistogram(bw, "fibonacci_request_duration_by_n_seconds", s.label, s.entry)
+	}
+
+	writeHeader(bw, "fibonacci_stats_approximate", "gauge", "1 if some keys are aggregated under the stats overflow row.")
+	approximate := 0
+	if h.stats.limiter.approximate() {
+		approximate = 1
+	}
+	fmt.Fprintf(bw, "fibonacci_stats_approximate %d\n", approximate)
+
+	writeHeader(bw, "fibonacci_stats_duplicates_dropped_total", "counter", "Retried stats records dropped because their event ID was already recorded.")
+	fmt.Fprintf(bw, "fibonacci_stats_duplicates_dropped_total %d\n", h.stats.dedup.duplicates())
+}
+
+// metricsSeries is the aggregate exported under one value of the n label.
+type metricsSeries struct {
+	label string
+	entry *Entry
+}
+
+// series returns the overall aggregate and one aggregate per exported 'n',
+// sorted by 'n' with "other" last.
+func (h *metricsHandler) series(entries map[Key]*Entry) (*Entry, []metricsSeries) {
+	byN := groupEntries(entries, []string{dimN}, nil)
+	keys := make([]Key, 0, len(byN))
+	total := &Entry{}
+	for k, e := range byN {
+		keys = append(keys, k)
+		total.Merge(e)
+	}
+
+	var other *Entry
+	if h.maxSeries > 0 {
+		labelled := h.label(byN)
+		kept := keys[:0]
+		for _, k := range keys {
+			if labelled[k.N] {
+				kept = append(kept, k)
+				continue
+			}
+			if other == nil {
+				other = &Entry{}
+			}
+			other.Merge(byN[k])
+		}
+		keys = kept
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].N < keys[j].N })
+
+	out := make([]metricsSeries, 0, len(keys)+1)
+	for _, k := range keys {
+		out = append(out, metricsSeries{label: strconv.Itoa(k.N), entry: byN[k]})
+	}
+	if other != nil {
+		out = append(out, metricsSeries{label: metricsOther, entry: other})
+	}
+	return total, out
+}
+
+// label returns the 'n' with their own series, first handing free series to
+// the most requested 'n' in byN. An 'n' only loses its series once its stats
+// are deleted.
+func (h *metricsHandler) label(byN map[Key]*Entry) map[int]bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.labelled == nil {
+		h.labelled = make(map[int]bool, h.maxSeries)
+	}
+	for n := range h.labelled {
+		if byN[Key{N: n}] == nil {
+			delete(h.labelled, n)
+		}
+	}
+
+	var candidates []Key
+	for k := range byN {
+		if !h.labelled[k.N] {
+			candidates = append(candidates, k)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if byN[candidates[i]].Count != byN[candidates[j]].Count {
+			return byN[candidates[i]].Count > byN[candidates[j]].Count
+		}
+		return candidates[i].N < candidates[j].N
+	})
+	for _, k := range candidates[:min(h.maxSeries-len(h.labelled), len(candidates))] {
+		h.labelled[k.N] = true
+	}
+
+	labelled := make(map[int]bool, len(h.labelled))
+	for n := range h.labelled {
+		labelled[n] = true
+	}
+	return labelled
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric family.
+func writeHeader(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// writeHistogram writes the cumulative buckets, sum and count of e's latency,
+// labelled with n unless it is empty.
+func writeHistogram(w *bufio.Writer, name, n string, e *Entry) {
+	counts := make([]int64, len(metricsBuckets))
+	for idx, c := range e.Latency.Counts {
+		_, hi := bucketBounds(idx)
+		i := sort.Search(len(metricsBuckets), func(i int) bool { return uint64(metricsBuckets[i]) >= hi })
+		if i < len(counts) {
+			counts[i] += c
+		}
+	}
+	nLabel := ""
+	if n != "" {
+		nLabel = fmt.Sprintf("n=%q", n)
+	}
+	var cumulative int64
+	for i, le := range metricsBuckets {
+		cumulative += counts[i]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, labelSet(nLabel, fmt.Sprintf("le=\"%g\"", le.Seconds())), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, labelSet(nLabel, `le="+Inf"`), e.Count)
+	fmt.Fprintf(w, "%s_sum%s %g\n", name, labelSet(nLabel), e.TotalTime.Seconds())
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labelSet(nLabel), e.Count)
+}
+
+// labelSet joins the non-empty label pairs into a {...} label set.
+func labelSet(pairs ...string) string {
+	var set []string
+	for _, p := range pairs {
+		if p != "" {
+			set = append(set, p)
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(set, ",") + "}"
+}
+
+// serveMetrics runs the /metrics HTTP listener on port.
+func serveMetrics(port string, h *metricsHandler) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", h)
+	log.Printf("Stats metrics listening on :%s/metrics", port)
+	if err := http.ListenAndServe(":"+port, mux); err != nil {
+		log.Fatalf("Failed to serve metrics: %v", err)
+	}
+}
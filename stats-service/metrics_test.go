@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMetricsSeriesStayLabelled(t *testing.T) {
+	h := &metricsHandler{maxSeries: 2}
+	counts := map[int]int64{}
+	steps := []struct {
+		name   string
+		record map[int]int64 // requests added per n
+		delete []int         // n whose stats are deleted
+		want   []string      // series labels
+	}{
+		{name: "under the limit", record: map[int]int64{1: 5}, want: []string{"1"}},
+		{name: "limit reached", record: map[int]int64{2: 1}, want: []string{"1", "2"}},
+		{name: "new n goes to other", record: map[int]int64{3: 2}, want: []string{"1", "2", metricsOther}},
+		{name: "labelled n keeps its series when overtaken", record: map[int]int64{3: 100}, want: []string{"1", "2", metricsOther}},
+		{name: "deleted n frees its series for the most requested", delete: []int{1}, record: map[int]int64{4: 1}, want: []string{"2", "3", metricsOther}},
+	}
+	for _, step := range steps {
+		for _, n := range step.delete {
+			delete(counts, n)
+		}
+		for n, c := range step.record {
+			counts[n] += c
+		}
+		entries := make(map[Key]*Entry, len(counts))
+		var want int64
+		for n, c := range counts {
+			e := &Entry{}
+			e.Add(Event{Key: Key{N: n}, Duration: time.Millisecond, Weight: c})
+			entries[Key{N: n}] = e
+			want += c
+		}
+
+		total, series := h.series(entries)
+		var labels []string
+		var sum int64
+		for _, s := range series {
+			labels = append(labels, s.label)
+			sum += s.entry.Count
+		}
+		if !slices.Equal(labels, step.want) {
+			t.Errorf("%s: series %v, want %v", step.name, labels, step.want)
+		}
+		if total.Count != want || sum != want {
+			t.Errorf("%s: total %d, series sum %d, want %d", step.name, total.Count, sum, want)
+		}
+	}
+}
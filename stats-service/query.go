@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+// statsSorters orders stats rows by the StatsRequest.sort_by field.
+// Rows that compare equal keep their order by 'n' and dimensions.
+var statsSorters = map[string]func(a, b *pb.FibonacciStat) bool{
+	"n":       func(a, b *pb.FibonacciStat) bool { return a.GetN() < b.GetN() },
+	"count":   func(a, b *pb.FibonacciStat) bool { return a.GetRequestCount() < b.GetRequestCount() },
+	"latency": func(a, b *pb.FibonacciStat) bool { return a.GetAverageTimeMs() < b.GetAverageTimeMs() },
+	"p99":     func(a, b *pb.FibonacciStat) bool { return a.GetP99Us() < b.GetP99Us() },
+}
+
+// inRange reports whether k.N lies within the request's min_n and max_n bounds.
+func inRange(k Key, in *pb.StatsRequest) bool {
+	if in.MinN != nil && k.N < int(in.GetMinN()) {
+		return false
+	}
+	if in.MaxN != nil && k.N > int(in.GetMaxN()) {
+		return false
+	}
+	return true
+}
+
+// sortStats orders rows in place by sortBy, which defaults to 'n'.
+func sortStats(rows []*pb.FibonacciStat, sortBy string, descending bool) error {
+	if sortBy == "" {
+		sortBy = "n"
+	}
+	less, ok := statsSorters[sortBy]
+	if !ok {
+		return fmt.Errorf("unknown sort_by %q (want n, count, latency or p99)", sortBy)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if descending {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+	return nil
+}
+
+// paginate returns the page of rows selected by token and pageSize, plus the
+// token for the following page. Tokens are opaque offsets, so rows recorded
+// between calls may shift a row across a page boundary.
+func paginate(rows []*pb.FibonacciStat, token string, pageSize int) ([]*pb.FibonacciStat, string, error) {
+	offset, err := decodePageToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	rows = rows[offset:]
+	if pageSize <= 0 || pageSize >= len(rows) {
+		return rows, "", nil
+	}
+	return rows[:pageSize], encodePageToken(offset + pageSize), nil
+}
+
+// encodePageToken returns the page token for a row offset.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodePageToken parses a token from encodePageToken; empty means the first page.
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page_token")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page_token")
+	}
+	return offset, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+// Run with several core counts to see recording scale, e.g.
+//
+//	go test -run '^$' -bench . -cpu 1,4,8
+
+// benchKeys is how many distinct keys the benchmarks spread events across.
+const benchKeys = 1000
+
+// lockedStore is the single-mutex in-memory store the sharded memoryStore
+// replaced, kept as a baseline for the benchmarks.
+type lockedStore struct {
+	mu      sync.Mutex
+	entries map[Key]*Entry
+}
+
+func (s *lockedStore) Record(ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[ev.Key]
+	if !ok {
+		e = &Entry{}
+		s.entries[ev.Key] = e
+	}
+	e.Add(ev)
+	return nil
+}
+
+func (s *lockedStore) Entries() (map[Key]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[Key]*Entry, len(s.entries))
+	for k, e := range s.entries {
+		out[k] = e.Clone()
+	}
+	return out, nil
+}
+
+func (s *lockedStore) Merge(Key, *Entry) error    { return nil }
+func (s *lockedStore) Delete(Key) (*Entry, error) { return nil, nil }
+func (s *lockedStore) Close() error               { return nil }
+
+// benchEvent returns the i-th event of a stream spread over benchKeys keys.
+func benchEvent(i int) Event {
+	return Event{
+		Key:      Key{N: i % benchKeys, Status: "OK"},
+		Duration: time.Duration(1000 + i%5000),
+		Time:     time.Now(),
+	}
+}
+
+// recordParallel records events into store from every benchmark goroutine.
+func recordParallel(b *testing.B, store StatsStore) {
+	var next atomic.Int64
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(next.Add(benchKeys / 7))
+		for pb.Next() {
+			store.Record(benchEvent(i))
+			i++
+		}
+	})
+}
+
+func BenchmarkStoreRecord(b *testing.B) {
+	b.Run("locked", func(b *testing.B) {
+		recordParallel(b, &lockedStore{entries: make(map[Key]*Entry)})
+	})
+	b.Run("sharded", func(b *testing.B) {
+		recordParallel(b, NewMemoryStore())
+	})
+}
+
+// BenchmarkStoreRecordWhileReading records while another goroutine reads
+// every aggregate in a loop, as a stream of GetStats calls would.
+func BenchmarkStoreRecordWhileReading(b *testing.B) {
+	run := func(b *testing.B, store StatsStore) {
+		for i := 0; i < benchKeys; i++ {
+			store.Record(benchEvent(i))
+		}
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					store.Entries()
+				}
+			}
+		}()
+		recordParallel(b, store)
+		close(stop)
+		<-done
+	}
+	b.Run("locked", func(b *testing.B) {
+		run(b, &lockedStore{entries: make(map[Key]*Entry)})
+	})
+	b.Run("sharded", func(b *testing.B) {
+		run(b, NewMemoryStore())
+	})
+}
+
+func BenchmarkLimiterAdmit(b *testing.B) {
+	l := newKeyLimiter(benchKeys)
+	for i := 0; i < benchKeys; i++ {
+		_, pin, _ := l.admit(Key{N: i, Status: "OK"}, 1)
+		pin.release()
+	}
+	var next atomic.Int64
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(next.Add(benchKeys / 7))
+		for pb.Next() {
+			_, pin, _ := l.admit(Key{N: i % benchKeys, Status: "OK"}, 1)
+			pin.release()
+			i++
+		}
+	})
+}
+
+// BenchmarkServiceRecord measures the whole recording path: limiter, store,
+// rolling windows, time series and watchers.
+func BenchmarkServiceRecord(b *testing.B) {
+	series, err := NewTimeSeries(defaultTiers, 0, time.Hour)
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := &statsService{
+		store:   NewMemoryStore(),
+		windows: NewRollingWindows(),
+		series:  series,
+		hub:     newWatchHub(),
+		limiter: newKeyLimiter(benchKeys * 10),
+	}
+	var next atomic.Int64
+	b.ReportAllocs()
+	b.RunParallel(func(p *testing.PB) {
+		i := int(next.Add(benchKeys / 7))
+		for p.Next() {
+			s.record(&pb.RecordRequest{N: int32(i % benchKeys), Duration: int64(1000 + i%5000)})
+			i++
+		}
+	})
+	b.StopTimer()
+	if _, err := s.GetStats(context.Background(), &pb.StatsRequest{}); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxGossipBytes bounds a gossip message, which carries whole replica states.
+const maxGossipBytes = 64 << 20
+
+// replicationMethodPrefix matches every StatsReplication RPC.
+const replicationMethodPrefix = "/stats.StatsReplication/"
+
+// peerToken is the bearer token replicas gossip with. It's required with STATS_PEERS.
+var peerToken = os.Getenv("STATS_PEER_TOKEN")
+
+// replicator lets several Stats replicas report the global statistics.
+//
+// Each replica's aggregates form its own state, which only that replica
+// changes, stamped with a version that increases on every change. Replicas
+// gossip states and keep the newest version of each, a state-based CRDT: the
+// per-replica counters and histograms only ever replace older copies of
+// themselves, so merging is idempotent and order-independent, and the global
+// view is the sum of the newest state of every replica.
+//
+// A nil replicator stands for a single unreplicated instance.
+type replicator struct {
+	pb.UnimplementedStatsReplicationServer
+	id        string
+	store     StatsStore       // this replica's own aggregates
+	instances *instanceTracker // when this replica last heard from each Fibonacci instance
+	dirty     atomic.Bool
+
+	mu     sync.RWMutex
+	local  *pb.ReplicaState         // latest snapshot of store
+	remote map[string]*replicaState // replica ID -> newest state received
+
+	// Used only by the gossip loop
+	conns map[string]*grpc.ClientConn // peer address -> connection
+	sent  map[string]int64            // peer address -> local version it holds
+}
+
+// replicaState is another replica's state, decoded for merging into queries.
+type replicaState struct {
+	version int64
+	proto   *pb.ReplicaState
+	entries map[Key]*Entry
+}
+
+// replicatedStore marks the replicator dirty whenever the local store changes.
+// It does so after each write, so a snapshot that clears the flag while the
+// write is in progress is followed by another.
+type replicatedStore struct {
+	StatsStore
+	dirty *atomic.Bool
+}
+
+func (s replicatedStore) Record(ev Event) error {
+	err := s.StatsStore.Record(ev)
+	if err == nil {
+		s.markDirty()
+	}
+	return err
+}
+
+func (s replicatedStore) Merge(k Key, e *Entry) error {
+	err := s.StatsStore.Merge(k, e)
+	if err == nil {
+		s.markDirty()
+	}
+	return err
+}
+
+func (s replicatedStore) Delete(k Key) (*Entry, error) {
+	e, err := s.StatsStore.Delete(k)
+	if err == nil {
+		s.markDirty()
+	}
+	return e, err
+}
+
+// markDirty sets the flag, loading it first so recording doesn't write the
+// shared cache line on every event.
+func (s replicatedStore) markDirty() {
+	if !s.dirty.Load() {
+		s.dirty.Store(true)
+	}
+}
+
+// newReplicator returns a replicator for the replica id whose aggregates are
+// in store and whose instance last-seen times are in instances.
+func newReplicator(id string, store StatsStore, instances *instanceTracker) *replicator {
+	r := &replicator{
+		id:        id,
+		store:     store,
+		instances: instances,
+		remote:    make(map[string]*replicaState),
+		conns:     make(map[string]*grpc.ClientConn),
+		sent:      make(map[string]int64),
+	}
+	r.dirty.Store(true)
+	return r
+}
+
+// wrap returns store, reporting its changes to the replicator.
+func (r *replicator) wrap(store StatsStore) StatsStore {
+	if r == nil {
+		return store
+	}
+	return replicatedStore{StatsStore: store, dirty: &r.dirty}
+}
+
+// mergeInto adds the other replicas' aggregates to entries.
+func (r *replicator) mergeInto(entries map[Key]*Entry) {
+	if r == nil {
+		return
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, st := range r.remote {
+		for k, e := range st.entries {
+			if cur, ok := entries[k]; ok {
+				cur.Merge(e)
+			} else {
+				entries[k] = e.Clone()
+			}
+		}
+	}
+}
+
+// mergeLastSeen raises the instance last-seen times in lastSeen to the newest any other replica heard of.
+func (r *replicator) mergeLastSeen(lastSeen map[string]int64) {
+	if r == nil {
+		return
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, st := range r.remote {
+		for instance, ms := range st.proto.GetLastSeenMs() {
+			lastSeen[instance] = max(lastSeen[instance], ms)
+		}
+	}
+}
+
+// replicas returns how many replicas the global view includes.
+func (r *replicator) replicas() int {
+	if r == nil {
+		return 1
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return 1 + len(r.remote)
+}
+
+// Gossip stores the caller's states and returns those newer than its versions.
+func (r *replicator) Gossip(_ context.Context, in *pb.GossipRequest) (*pb.GossipResponse, error) {
+	r.merge(in.GetStates())
+	return &pb.GossipResponse{States: r.newer(in.GetVersions()), Versions: r.versions()}, nil
+}
+
+// merge keeps every state that is newer than the one held for its replica.
+func (r *replicator) merge(states []*pb.ReplicaState) {
+	for _, st := range states {
+		id := st.GetReplicaId()
+		if id == "" || id == r.id {
+			continue
+		}
+		r.mu.RLock()
+		cur := r.remote[id]
+		r.mu.RUnlock()
+		if cur != nil && cur.version >= st.GetVersion() {
+			continue
+		}
+
+		entries := make(map[Key]*Entry, len(st.GetRecords()))
+		for _, rec := range st.GetRecords() {
+			k, e, err := entryFromRecord(rec)
+			if err != nil {
+				log.Printf("Ignoring state of replica %s: %v", id, err)
+				entries = nil
+				break
+			}
+			entries[k] = e
+		}
+		if entries == nil {
+			continue
+		}
+
+		r.mu.Lock()
+		if cur := r.remote[id]; cur == nil || cur.version < st.GetVersion() {
+			if cur == nil {
+				log.Printf("Replica %s joined", id)
+			}
+			r.remote[id] = &replicaState{version: st.GetVersion(), proto: st, entries: entries}
+		}
+		r.mu.Unlock()
+	}
+}
+
+// newer returns the states held that are newer than versions.
+func (r *replicator) newer(versions map[string]int64) []*pb.ReplicaState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []*pb.ReplicaState
+	if r.local != nil && r.local.GetVersion() > versions[r.id] {
+		out = append(out, r.local)
+	}
+	for id, st := range r.remote {
+		if st.version > versions[id] {
+			out = append(out, st.proto)
+		}
+	}
+	return out
+}
+
+// versions returns the newest version held of every replica.
+func (r *replicator) versions() map[string]int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]int64, len(r.remote)+1)
+	if r.local != nil {
+		out[r.id] = r.local.GetVersion()
+	}
+	for id, st := range r.remote {
+		out[id] = st.version
+	}
+	return out
+}
+
+// refresh snapshots the local store into a new version if it changed.
+func (r *replicator) refresh() error {
+	if !r.dirty.Swap(false) {
+		return nil
+	}
+	entries, err := r.store.Entries()
+	if err != nil {
+		r.dirty.Store(true)
+		return err
+	}
+
+	// Versions are timestamps so they keep increasing across restarts
+	version := time.Now().UnixNano()
+	r.mu.RLock()
+	if r.local != nil && version <= r.local.GetVersion() {
+		version = r.local.GetVersion() + 1
+	}
+	r.mu.RUnlock()
+
+	st := &pb.ReplicaState{ReplicaId: r.id, Version: version, LastSeenMs: r.instances.lastSeen()}
+	for k, e := range entries {
+		st.Records = append(st.Records, statsRecord(k, e))
+	}
+	r.mu.Lock()
+	r.local = st
+	r.mu.Unlock()
+	return nil
+}
+
+// run gossips with peers every interval. Peers are host:port addresses; a
+// host resolving to several addresses, such as a scaled compose service, is
+// gossiped with at each of them.
+func (r *replicator) run(peers []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := r.refresh(); err != nil {
+			log.Printf("Failed to snapshot stats for gossip: %v", err)
+		}
+		for _, addr := range resolvePeers(peers) {
+			r.gossipWith(addr)
+		}
+	}
+}
+
+// gossipWith exchanges states with the replica at addr.
+func (r *replicator) gossipWith(addr string) {
+	conn, ok := r.conns[addr]
+	if !ok {
+		var err error
+		conn, err = grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Printf("Failed to connect to replica %s: %v", addr, err)
+			return
+		}
+		r.conns[addr] = conn
+	}
+
+	req := &pb.GossipRequest{ReplicaId: r.id, Versions: r.versions()}
+	r.mu.RLock()
+	local := r.local
+	r.mu.RUnlock()
+	if local != nil && local.GetVersion() > r.sent[addr] {
+		req.States = append(req.States, local)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+peerToken)
+	res, err := pb.NewStatsReplicationClient(conn).Gossip(ctx, req, grpc.MaxCallRecvMsgSize(maxGossipBytes))
+	if err != nil {
+		log.Printf("Gossip with replica %s failed: %v", addr, err)
+		return
+	}
+	// A restarted peer reports an older version, so the state is pushed again
+	r.sent[addr] = res.GetVersions()[r.id]
+	r.merge(res.GetStates())
+}
+
+// resolvePeers expands each host:port into one address per IP of the host.
+func resolvePeers(peers []string) []string {
+	var addrs []string
+	for _, peer := range peers {
+		host, port, err := net.SplitHostPort(peer)
+		if err != nil {
+			log.Printf("Invalid peer %q: %v", peer, err)
+			continue
+		}
+		ips, err := net.LookupHost(host)
+		if err != nil {
+			log.Printf("Failed to resolve peer %s: %v", peer, err)
+			continue
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, net.JoinHostPort(ip, port))
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// replicaStateOf returns the state of replica id at version holding count
+// requests for each n in counts.
+func replicaStateOf(id string, version int64, counts map[int]int64) *pb.ReplicaState {
+	st := &pb.ReplicaState{ReplicaId: id, Version: version}
+	for n, count := range counts {
+		e := &Entry{}
+		e.Add(Event{Key: Key{N: n}, Duration: time.Millisecond, Weight: count})
+		st.Records = append(st.Records, statsRecord(Key{N: n}, e))
+	}
+	return st
+}
+
+func TestReplicatorMerge(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []*pb.ReplicaState // merged one at a time, in order
+		want   map[int]int64      // global count per n, local store empty
+		peers  int                // replicas in the global view
+	}{
+		{
+			name:   "one replica",
+			states: []*pb.ReplicaState{replicaStateOf("b", 1, map[int]int64{5: 3})},
+			want:   map[int]int64{5: 3},
+			peers:  2,
+		},
+		{
+			name: "newer version replaces older",
+			states: []*pb.ReplicaState{
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+				replicaStateOf("b", 2, map[int]int64{5: 7, 6: 1}),
+			},
+			want:  map[int]int64{5: 7, 6: 1},
+			peers: 2,
+		},
+		{
+			name: "older version arriving late is ignored",
+			states: []*pb.ReplicaState{
+				replicaStateOf("b", 2, map[int]int64{5: 7}),
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+			},
+			want:  map[int]int64{5: 7},
+			peers: 2,
+		},
+		{
+			name: "same state twice is counted once",
+			states: []*pb.ReplicaState{
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+			},
+			want:  map[int]int64{5: 3},
+			peers: 2,
+		},
+		{
+			name: "replicas are summed",
+			states: []*pb.ReplicaState{
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+				replicaStateOf("c", 1, map[int]int64{5: 4, 8: 2}),
+			},
+			want:  map[int]int64{5: 7, 8: 2},
+			peers: 3,
+		},
+		{
+			name: "own state is ignored",
+			states: []*pb.ReplicaState{
+				replicaStateOf("a", 9, map[int]int64{5: 3}),
+			},
+			want:  map[int]int64{},
+			peers: 1,
+		},
+		{
+			name: "invalid state is ignored",
+			states: []*pb.ReplicaState{
+				replicaStateOf("b", 1, map[int]int64{5: 3}),
+				{ReplicaId: "b", Version: 2, Records: []*pb.StatsRecord{{N: 5, Count: 1, Errors: 2}}},
+			},
+			want:  map[int]int64{5: 3},
+			peers: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReplicator("a", NewMemoryStore(), nil)
+			for _, st := range tt.states {
+				r.merge([]*pb.ReplicaState{st})
+			}
+			entries := make(map[Key]*Entry)
+			r.mergeInto(entries)
+			got := make(map[int]int64, len(entries))
+			for k, e := range entries {
+				got[k.N] += e.Count
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("global view = %v, want %v", got, tt.want)
+			}
+			for n, count := range tt.want {
+				if got[n] != count {
+					t.Errorf("count for n=%d = %d, want %d", n, got[n], count)
+				}
+			}
+			if got := r.replicas(); got != tt.peers {
+				t.Errorf("replicas() = %d, want %d", got, tt.peers)
+			}
+		})
+	}
+}
+
+// TestReplicatorConverges checks that replicas gossiping in any order end up
+// with the same global view.
+func TestReplicatorConverges(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	replicas := make([]*replicator, len(ids))
+	for i, id := range ids {
+		replicas[i] = newReplicator(id, NewMemoryStore(), nil)
+		store := replicas[i].wrap(replicas[i].store)
+		for j := 0; j <= i; j++ {
+			if err := store.Record(Event{Key: Key{N: 10 + j}, Duration: time.Millisecond}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := replicas[i].refresh(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A ring of exchanges, in both directions, reaches every replica twice over
+	exchange := func(from, to *replicator) {
+		res, _ := to.Gossip(context.Background(), &pb.GossipRequest{ReplicaId: from.id, Versions: from.versions(), States: from.newer(nil)})
+		from.merge(res.GetStates())
+	}
+	for range 2 {
+		exchange(replicas[0], replicas[1])
+		exchange(replicas[2], replicas[1])
+		exchange(replicas[0], replicas[2])
+	}
+
+	want := map[int]int64{10: 3, 11: 2, 12: 1}
+	for _, r := range replicas {
+		entries, err := r.store.Entries()
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.mergeInto(entries)
+		for n, count := range want {
+			if got := entries[Key{N: n}].Count; got != count {
+				t.Errorf("replica %s: count for n=%d = %d, want %d", r.id, n, got, count)
+			}
+		}
+	}
+}
+
+func TestReplicatedStoreMarksDirty(t *testing.T) {
+	r := newReplicator("a", NewMemoryStore(), nil)
+	store := r.wrap(r.store)
+	if err := r.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	first := r.local.GetVersion()
+
+	if err := r.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.local.GetVersion(); got != first {
+		t.Fatalf("refresh without changes made version %d, want %d", got, first)
+	}
+
+	if err := store.Record(Event{Key: Key{N: 1}, Duration: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.local.GetVersion(); got <= first {
+		t.Fatalf("refresh after a write kept version %d", got)
+	}
+	if got := len(r.local.GetRecords()); got != 1 {
+		t.Fatalf("snapshot holds %d records, want 1", got)
+	}
+}
+
+func TestAuthorizePeer(t *testing.T) {
+	const gossip = replicationMethodPrefix + "Gossip"
+	tests := []struct {
+		name   string
+		token  string // STATS_PEER_TOKEN
+		method string
+		auth   string // authorization metadata, if any
+		want   codes.Code
+	}{
+		{name: "valid token", token: "secret", method: gossip, auth: "Bearer secret", want: codes.OK},
+		{name: "wrong token", token: "secret", method: gossip, auth: "Bearer guess", want: codes.Unauthenticated},
+		{name: "no token", token: "secret", method: gossip, want: codes.Unauthenticated},
+		{name: "replication disabled", method: gossip, auth: "Bearer ", want: codes.PermissionDenied},
+		{name: "other service", token: "secret", method: "/stats.Stats/GetStats", want: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(tok string) { peerToken = tok }(peerToken)
+			peerToken = tt.token
+
+			ctx := context.Background()
+			if tt.auth != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.auth))
+			}
+			if err := authorizePeer(ctx, tt.method); status.Code(err) != tt.want {
+				t.Errorf("authorizePeer error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalOnlyQueriesRejectedWithReplication(t *testing.T) {
+	s := &statsService{store: NewMemoryStore(), windows: NewRollingWindows(), replicas: newReplicator("a", NewMemoryStore(), nil)}
+	if _, err := s.GetStats(context.Background(), &pb.StatsRequest{}); err != nil {
+		t.Errorf("all-time GetStats: %v", err)
+	}
+	if _, err := s.GetStats(context.Background(), &pb.StatsRequest{Window: "5m"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("windowed GetStats error = %v, want FailedPrecondition", err)
+	}
+	if _, err := s.QueryStats(context.Background(), &pb.QueryStatsRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("QueryStats error = %v, want FailedPrecondition", err)
+	}
+	if _, err := s.GetSlowLog(context.Background(), &pb.GetSlowLogRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetSlowLog error = %v, want FailedPrecondition", err)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+)
+
+// slowLog keeps the most recent computations at least threshold slow in a
+// ring buffer, so outliers hidden by averages can be inspected. A nil slow
+// log keeps nothing.
+type slowLog struct {
+	threshold time.Duration
+
+	mu    sync.Mutex
+	ring  []*pb.SlowRequest
+	next  int   // slot the next entry is written to
+	size  int   // entries held
+	total int64 // entries ever logged
+}
+
+// newSlowLog returns a slow log holding up to size entries, or nil if size is not positive.
+func newSlowLog(size int, threshold time.Duration) *slowLog {
+	if size <= 0 {
+		return nil
+	}
+	return &slowLog{threshold: threshold, ring: make([]*pb.SlowRequest, size)}
+}
+
+// add logs r, served at t, if it is slow enough.
+func (l *slowLog) add(r *pb.RecordRequest, at time.Time) {
+	if l == nil || time.Duration(r.GetDuration()) < l.threshold {
+		return
+	}
+	e := &pb.SlowRequest{
+		N:           r.GetN(),
+		DurationUs:  micros(time.Duration(r.GetDuration())),
+		InstanceId:  r.GetInstanceId(),
+		TimestampMs: at.UnixMilli(),
+		CacheStatus: r.GetCacheStatus(),
+		RequestId:   r.GetRequestId(),
+		Algorithm:   r.GetAlgorithm(),
+		StatusCode:  r.GetStatusCode(),
+		ClientId:    r.GetClientId(),
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ring[l.next] = e
+	l.next = (l.next + 1) % len(l.ring)
+	l.size = min(l.size+1, len(l.ring))
+	l.total++
+}
+
+// reset empties the log.
+func (l *slowLog) reset() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.ring)
+	l.next, l.size = 0, 0
+}
+
+// list returns the logged entries matching in, newest first.
+func (l *slowLog) list(in *pb.GetSlowLogRequest) *pb.GetSlowLogResponse {
+	res := &pb.GetSlowLogResponse{}
+	if l == nil {
+		return res
+	}
+	res.ThresholdUs = micros(l.threshold)
+	res.Capacity = int32(len(l.ring))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res.TotalSlow = l.total
+	for i := 0; i < l.size; i++ {
+		if limit := int(in.GetLimit()); limit > 0 && len(res.Entries) >= limit {
+			break
+		}
+		e := l.ring[(l.next-1-i+len(l.ring))%len(l.ring)]
+		if e.GetDurationUs() < in.GetMinDurationUs() {
+			continue
+		}
+		if len(in.GetN()) > 0 && !slices.Contains(in.GetN(), e.GetN()) {
+			continue
+		}
+		res.Entries = append(res.Entries, e)
+	}
+	return res
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"hash/maphash"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event is a single Fibonacci computation reported by the Fibonacci service.
+type Event struct {
+	Key                      // 'n' and the request's dimensions
+	Duration   time.Duration // computation time
+	Time       time.Time     // when the request was served
+	ResultSize int           // size of the result in bytes
+	Weight     int64         // requests the event stands for when sampled; 0 counts as 1
+}
+
+// weight returns how many requests the event stands for.
+func (ev Event) weight() int64 {
+	return max(ev.Weight, 1)
+}
+
+// failed reports whether the request ended with a non-OK gRPC status.
+func (ev Event) failed() bool {
+	return ev.Status != "" && ev.Status != "OK"
+}
+
+// Entry holds the aggregated statistics for a single Key.
+type Entry struct {
+	Count       int64         `json:"count"`        // Number of requests
+	TotalTime   time.Duration `json:"total_time"`   // Total processing time
+	Min         time.Duration `json:"min"`          // Fastest request
+	Max         time.Duration `json:"max"`          // Slowest request
+	Latency     Histogram     `json:"latency"`      // Distribution of processing times
+	ResultBytes int64         `json:"result_bytes"` // Total size of the results
+	Errors      int64         `json:"errors"`       // Requests that failed
+}
+
+// Add folds ev into the entry, scaled up by its sample weight.
+func (e *Entry) Add(ev Event) {
+	w := ev.weight()
+	if e.Count == 0 || ev.Duration < e.Min {
+		e.Min = ev.Duration
+	}
+	if ev.Duration > e.Max {
+		e.Max = ev.Duration
+	}
+	e.Count += w
+	e.TotalTime += ev.Duration * time.Duration(w)
+	e.ResultBytes += int64(ev.ResultSize) * w
+	if ev.failed() {
+		e.Errors += w
+	}
+	e.Latency.ObserveN(ev.Duration, w)
+}
+
+// Merge folds another aggregate into e.
+func (e *Entry) Merge(o *Entry) {
+	if o.Count == 0 {
+		return
+	}
+	if e.Count == 0 || o.Min < e.Min {
+		e.Min = o.Min
+	}
+	if o.Max > e.Max {
+		e.Max = o.Max
+	}
+	e.Count += o.Count
+	e.TotalTime += o.TotalTime
+	e.ResultBytes += o.ResultBytes
+	e.Errors += o.Errors
+	e.Latency.Merge(&o.Latency)
+}
+
+// Clone returns a deep copy of e.
+func (e *Entry) Clone() *Entry {
+	c := *e
+	c.Latency = *e.Latency.Clone()
+	return &c
+}
+
+// StatsStore persists aggregated statistics keyed by 'n' and dimensions.
+// Implementations must be safe for concurrent use.
+type StatsStore interface {
+	// Record adds a single event to the aggregate for ev.Key.
+	Record(ev Event) error
+	// Entries returns a copy of every aggregate.
+	Entries() (map[Key]*Entry, error)
+	// Merge folds an existing aggregate into the one for k.
+	Merge(k Key, e *Entry) error
+	// Delete removes the aggregate for k and returns it, or nil if there was none.
+	Delete(k Key) (*Entry, error)
+	// Close releases the underlying storage.
+	Close() error
+}
+
+// NewStoreFromEnv builds the store selected by STATS_STORE:
+//
+//	memory (default)  process-local, lost on restart
+//	bolt              embedded file at STATS_DB_PATH (default stats.db)
+//	redis             shared Redis at STATS_REDIS_ADDR (default redis:6379)
+func NewStoreFromEnv() (StatsStore, error) {
+	switch kind := os.Getenv("STATS_STORE"); kind {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "bolt":
+		return NewBoltStore(getenv("STATS_DB_PATH", "stats.db"))
+	case "redis":
+		return NewRedisStore(getenv("STATS_REDIS_ADDR", "redis:6379"), getenv("STATS_REDIS_PREFIX", "stats:"))
+	default:
+		return nil, fmt.Errorf("unknown STATS_STORE %q (want memory, bolt or redis)", kind)
+	}
+}
+
+// getenv returns the environment variable name, or def if it is unset.
+func getenv(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// recordShards is the number of independently locked shards the in-memory
+// structures split keys across, so concurrent recordings of different keys
+// rarely contend. It must be a power of two.
+const recordShards = 32
+
+// shardSeed seeds the hash that assigns keys to shards.
+var shardSeed = maphash.MakeSeed()
+
+// shardOf returns the shard index of k.
+func shardOf(k Key) int {
+	return int(maphash.Comparable(shardSeed, k) & (recordShards - 1))
+}
+
+// memoryStore keeps statistics in process memory, sharded by key.
+type memoryStore struct {
+	shards [recordShards]memoryShard
+}
+
+// memoryShard holds the aggregates of the keys assigned to one shard.
+type memoryShard struct {
+	mu      sync.Mutex
+	entries map[Key]*Entry
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *memoryStore {
+	m := &memoryStore{}
+	for i := range m.shards {
+		m.shards[i].entries = make(map[Key]*Entry)
+	}
+	return m
+}
+
+// Record adds ev to the in-memory aggregate.
+func (m *memoryStore) Record(ev Event) error {
+	sh := &m.shards[shardOf(ev.Key)]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	e, ok := sh.entries[ev.Key]
+	if !ok {
+		e = &Entry{}
+		sh.entries[ev.Key] = e
+	}
+	e.Add(ev)
+	return nil
+}
+
+// Entries returns a copy of every aggregate. Shards are copied one at a time,
+// so a large read only ever blocks recording into a single shard.
+func (m *memoryStore) Entries() (map[Key]*Entry, error) {
+	out := make(map[Key]*Entry)
+	for i := range m.shards {
+		sh := &m.shards[i]
+		sh.mu.Lock()
+		for k, e := range sh.entries {
+			out[k] = e.Clone()
+		}
+		sh.mu.Unlock()
+	}
+	return out, nil
+}
+
+// Merge folds e into the in-memory aggregate for k.
+func (m *memoryStore) Merge(k Key, e *Entry) error {
+	sh := &m.shards[shardOf(k)]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	if agg, ok := sh.entries[k]; ok {
+		agg.Merge(e)
+	} else {
+		sh.entries[k] = e.Clone()
+	}
+	return nil
+}
+
+// Delete removes the in-memory aggregate for k.
+func (m *memoryStore) Delete(k Key) (*Entry, error) {
+	sh := &m.shards[shardOf(k)]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	e := sh.entries[k]
+	delete(sh.entries, k)
+	return e, nil
+}
+
+// Close is a no-op for the in-memory store.
+func (m *memoryStore) Close() error {
+	return nil
+}
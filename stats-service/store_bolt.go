@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// entriesBucket holds one JSON-encoded Entry per encoded Key.
+var entriesBucket = []byte("entries")
+
+// boltStore keeps statistics in an embedded BoltDB file so they survive restarts.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (or creates) the database file at path.
+func NewBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(entriesBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+// Record adds ev to the stored aggregate. Concurrent calls are coalesced into
+// a single write transaction by bolt's Batch.
+func (b *boltStore) Record(ev Event) error {
+	return b.update(ev.Key, func(e *Entry) { e.Add(ev) })
+}
+
+// Merge folds e into the stored aggregate for k.
+func (b *boltStore) Merge(k Key, e *Entry) error {
+	return b.update(k, func(agg *Entry) { agg.Merge(e) })
+}
+
+// update applies f to the stored aggregate for k in a batched transaction.
+func (b *boltStore) update(k Key, f func(*Entry)) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(entriesBucket)
+		key := k.encode()
+
+		var e Entry
+		if raw := bucket.Get(key); raw != nil {
+			if err := json.Unmarshal(raw, &e); err != nil {
+				return fmt.Errorf("decoding entry for %s: %w", key, err)
+			}
+		}
+		f(&e)
+
+		raw, err := json.Marshal(&e)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(key, raw)
+	})
+}
+
+// Delete removes the stored aggregate for k, including any copy stored under
+// its pre-dimensions key.
+func (b *boltStore) Delete(k Key) (*Entry, error) {
+	var out *Entry
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(entriesBucket)
+		keys := [][]byte{k.encode()}
+		if legacy, ok := k.legacyEncode(); ok {
+			keys = append(keys, legacy)
+		}
+		for _, key := range keys {
+			raw := bucket.Get(key)
+			if raw == nil {
+				continue
+			}
+			var e Entry
+			if err := json.Unmarshal(raw, &e); err != nil {
+				return fmt.Errorf("decoding entry for %s: %w", key, err)
+			}
+			if out == nil {
+				out = &e
+			} else {
+				out.Merge(&e)
+			}
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Entries reads every stored aggregate.
+func (b *boltStore) Entries() (map[Key]*Entry, error) {
+	out := make(map[Key]*Entry)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(entriesBucket).ForEach(func(k, v []byte) error {
+			key, err := decodeKey(k)
+			if err != nil {
+				return err
+			}
+			var e Entry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return fmt.Errorf("decoding entry for %s: %w", k, err)
+			}
+			if prev, ok := out[key]; ok {
+				prev.Merge(&e)
+			} else {
+				out[key] = &e
+			}
+			return nil
+		})
+	})
+	return out, err
+}
+
+// Close closes the database file.
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTxRetries bounds optimistic-lock retries when replicas update the same 'n'.
+const redisTxRetries = 10
+
+// redisStore keeps statistics in Redis so several stats replicas can share them.
+// Each Key's JSON-encoded Entry lives under <prefix>e:<encoded key>, and the
+// set <prefix>ns indexes every encoded key.
+type redisStore struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// NewRedisStore connects to Redis at addr and namespaces every key with prefix.
+func NewRedisStore(addr, prefix string) (*redisStore, error) {
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("connecting to redis at %s: %w", addr, err)
+	}
+	return &redisStore{rdb: rdb, prefix: prefix}, nil
+}
+
+// entryKey returns the Redis key holding the aggregate for an encoded Key.
+// Entries written before dimensions existed are indexed by bare 'n' and
+// live under <prefix>n:<n>.
+func (r *redisStore) entryKey(encoded string) string {
+	if _, err := strconv.Atoi(encoded); err == nil {
+		return r.prefix + "n:" + encoded
+	}
+	return r.prefix + "e:" + encoded
+}
+
+// indexKey returns the Redis set listing every recorded Key.
+func (r *redisStore) indexKey() string {
+	return r.prefix + "ns"
+}
+
+// Record adds ev to the aggregate for ev.Key using WATCH/MULTI, so concurrent
+// writers on other replicas never lose an update.
+func (r *redisStore) Record(ev Event) error {
+	return r.update(ev.Key, func(e *Entry) { e.Add(ev) })
+}
+
+// Merge folds e into the aggregate for k.
+func (r *redisStore) Merge(k Key, e *Entry) error {
+	return r.update(k, func(agg *Entry) { agg.Merge(e) })
+}
+
+// update applies f to the aggregate for k inside an optimistic transaction.
+func (r *redisStore) update(k Key, f func(*Entry)) error {
+	ctx := context.Background()
+	member := string(k.encode())
+	key := r.entryKey(member)
+
+	update := func(tx *redis.Tx) error {
+		var e Entry
+		raw, err := tx.Get(ctx, key).Bytes()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(raw, &e); err != nil {
+				return fmt.Errorf("decoding entry for %s: %w", member, err)
+			}
+		}
+		f(&e)
+		if raw, err = json.Marshal(&e); err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, raw, 0)
+			pipe.SAdd(ctx, r.indexKey(), member)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < redisTxRetries; i++ {
+		err := r.rdb.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("recording %s: too much contention", member)
+}
+
+// Delete removes the aggregate for k, including any copy stored under its
+// pre-dimensions key, and drops it from the index.
+func (r *redisStore) Delete(k Key) (*Entry, error) {
+	ctx := context.Background()
+	members := []string{string(k.encode())}
+	if legacy, ok := k.legacyEncode(); ok {
+		members = append(members, string(legacy))
+	}
+
+	var out *Entry
+	for _, member := range members {
+		key := r.entryKey(member)
+		pipe := r.rdb.TxPipeline()
+		get := pipe.GetDel(ctx, key)
+		pipe.SRem(ctx, r.indexKey(), member)
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return out, err
+		}
+		raw, err := get.Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		var e Entry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return out, fmt.Errorf("decoding entry for %s: %w", member, err)
+		}
+		if out == nil {
+			out = &e
+		} else {
+			out.Merge(&e)
+		}
+	}
+	return out, nil
+}
+
+// Entries reads every stored aggregate.
+func (r *redisStore) Entries() (map[Key]*Entry, error) {
+	ctx := context.Background()
+	members, err := r.rdb.SMembers(ctx, r.indexKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[Key]*Entry, len(members))
+	if len(members) == 0 {
+		return out, nil
+	}
+	keys := make([]string, len(members))
+	for i, m := range members {
+		keys[i] = r.entryKey(m)
+	}
+
+	vals, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			continue // indexed but deleted
+		}
+		key, err := decodeKey([]byte(members[i]))
+		if err != nil {
+			return nil, err
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			return nil, fmt.Errorf("decoding entry for %s: %w", members[i], err)
+		}
+		if prev, ok := out[key]; ok {
+			prev.Merge(&e)
+		} else {
+			out[key] = &e
+		}
+	}
+	return out, nil
+}
+
+// Close closes the Redis connection.
+func (r *redisStore) Close() error {
+	return r.rdb.Close()
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultTiers is used when STATS_TS_TIERS is unset: 10s buckets for 6 hours,
+	// 1m buckets for a week and 1h buckets for 90 days.
+	defaultTiers = "10s:6h,1m:168h,1h:2160h"
+
+	// maxSeriesPoints bounds the size of a single QueryStats response.
+	maxSeriesPoints = 5000
+
+	// defaultSeriesPoints is the resolution picked when the caller omits the step.
+	defaultSeriesPoints = 300
+)
+
+// tsTier stores aggregates at one resolution for one retention period.
+// Every event is recorded into every tier, so coarser tiers are exact
+// downsamples of finer ones.
+type tsTier struct {
+	resolution time.Duration
+	retention  time.Duration
+	buckets    map[int64]map[int]*Entry // bucket index (unix ns / resolution) -> per-n aggregates
+	pruned     int64                    // last bucket index at which old buckets were dropped
+}
+
+// parseTiers parses a spec such as "10s:6h,1m:168h" into tiers ordered from finest to coarsest.
+func parseTiers(spec string) ([]*tsTier, error) {
+	var tiers []*tsTier
+	for _, part := range strings.Split(spec, ",") {
+		res, ret, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid tier %q, want resolution:retention", part)
+		}
+		resolution, err := time.ParseDuration(res)
+		if err != nil || resolution <= 0 {
+			return nil, fmt.Errorf("invalid tier resolution %q", res)
+		}
+		retention, err := time.ParseDuration(ret)
+		if err != nil || retention < resolution {
+			return nil, fmt.Errorf("invalid tier retention %q", ret)
+		}
+		tiers = append(tiers, &tsTier{resolution: resolution, retention: retention, buckets: make(map[int64]map[int]*Entry)})
+	}
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].resolution < tiers[j].resolution })
+	return tiers, nil
+}
+
+// record adds ev to the bucket containing now and drops buckets past retention.
+func (t *tsTier) record(ev Event, now time.Time) {
+	idx := now.UnixNano() / int64(t.resolution)
+	bucket, ok := t.buckets[idx]
+	if !ok {
+		bucket = make(map[int]*Entry)
+		t.buckets[idx] = bucket
+	}
+	e, ok := bucket[ev.N]
+	if !ok {
+		e = &Entry{}
+		bucket[ev.N] = e
+	}
+	e.Add(ev)
+
+	if idx != t.pruned {
+		t.pruned = idx
+		oldest := now.Add(-t.retention).UnixNano() / int64(t.resolution)
+		for b := range t.buckets {
+			if b < oldest {
+				delete(t.buckets, b)
+			}
+		}
+	}
+}
+
+// TimeSeries keeps downsampled per-n aggregates over time. Values of 'n'
+// are split across independently locked shards, each with its own tiers.
+//
+// Every tier holds a bucket per resolution step for each 'n', so memory grows
+// with the number of 'n' that have their own series. At most maxSeries do; the
+// requests of any other 'n' are recorded under overflowKey. While the limit
+// is reached, series idle for longer than idle are folded into overflowKey to
+// make room. Series summed over every 'n' stay exact.
+type TimeSeries struct {
+	shards    [recordShards]tsShard
+	maxSeries int64 // 0 for no limit
+	idle      time.Duration
+	count     atomic.Int64 // 'n' with their own series
+}
+
+// tsShard holds every tier for the values of 'n' assigned to it.
+type tsShard struct {
+	mu     sync.Mutex
+	tiers  []*tsTier
+	series map[int]time.Time // 'n' with its own series -> when it was last recorded
+}
+
+// NewTimeSeries builds a time series store from a tier spec (see
+// defaultTiers), keeping separate series for at most maxSeries values of 'n'
+// (0 for no limit) and retiring those idle for longer than idle when full.
+func NewTimeSeries(spec string, maxSeries int, idle time.Duration) (*TimeSeries, error) {
+	ts := &TimeSeries{maxSeries: int64(max(maxSeries, 0)), idle: idle}
+	for i := range ts.shards {
+		tiers, err := parseTiers(spec)
+		if err != nil {
+			return nil, err
+		}
+		ts.shards[i].tiers = tiers
+		ts.shards[i].series = make(map[int]time.Time)
+	}
+	return ts, nil
+}
+
+// Record adds ev to every tier.
+func (ts *TimeSeries) Record(ev Event) {
+	now := time.Now()
+	sh := &ts.shards[shardOf(Key{N: ev.N})]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if !ts.admit(sh, ev.N, now) {
+		ev.Key = overflowKey
+	}
+	for _, t := range sh.tiers {
+		t.record(ev, now)
+	}
+}
+
+// admit reports whether n has, or can be given, its own series, marking it
+// recorded at now. The caller holds sh.mu.
+func (ts *TimeSeries) admit(sh *tsShard, n int, now time.Time) bool {
+	if _, ok := sh.series[n]; !ok && n != overflowKey.N {
+		for {
+			count := ts.count.Load()
+			if ts.maxSeries > 0 && count >= ts.maxSeries {
+				return false
+			}
+			if ts.count.CompareAndSwap(count, count+1) {
+				break
+			}
+		}
+	}
+	sh.series[n] = now
+	return true
+}
+
+// run retires idle series every interval while the limit is reached.
+func (ts *TimeSeries) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if ts.maxSeries > 0 && ts.count.Load() >= ts.maxSeries {
+			if n := ts.retireIdle(now); n > 0 {
+				log.Printf("Time series full: folded %d idle series into n=%d", n, overflowKey.N)
+			}
+		}
+	}
+}
+
+// retireIdle folds every series not recorded since idle before now into
+// overflowKey and returns how many there were.
+func (ts *TimeSeries) retireIdle(now time.Time) int {
+	retired := 0
+	for i := range ts.shards {
+		sh := &ts.shards[i]
+		sh.mu.Lock()
+		for n, at := range sh.series {
+			if n != overflowKey.N && now.Sub(at) > ts.idle {
+				sh.fold(n)
+				ts.count.Add(-1)
+				retired++
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return retired
+}
+
+// fold moves n's history into overflowKey and ends its series. The caller holds sh.mu.
+func (sh *tsShard) fold(n int) {
+	delete(sh.series, n)
+	for _, t := range sh.tiers {
+		for _, bucket := range t.buckets {
+			e, ok := bucket[n]
+			if !ok {
+				continue
+			}
+			delete(bucket, n)
+			if agg, ok := bucket[overflowKey.N]; ok {
+				agg.Merge(e)
+			} else {
+				bucket[overflowKey.N] = e
+			}
+		}
+	}
+}
+
+// Forget drops the aggregates of every 'n' matching match from all tiers.
+func (ts *TimeSeries) Forget(match func(n int) bool) {
+	for i := range ts.shards {
+		sh := &ts.shards[i]
+		sh.mu.Lock()
+		for _, t := range sh.tiers {
+			for _, bucket := range t.buckets {
+				for n := range bucket {
+					if match(n) {
+						delete(bucket, n)
+					}
+				}
+			}
+		}
+		for n := range sh.series {
+			if match(n) {
+				delete(sh.series, n)
+				if n != overflowKey.N {
+					ts.count.Add(-1)
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+}
+
+// SeriesPoint is the merged aggregates of one step of a query.
+type SeriesPoint struct {
+	Start   time.Time
+	Entries map[int]*Entry
+}
+
+// Query returns one point per step in [from, to). It reads from the finest
+// tier that still retains from, and rounds step up to that tier's resolution
+// (picking a step automatically when step is zero). If ns is non-empty only
+// those values of 'n' are returned.
+func (ts *TimeSeries) Query(from, to time.Time, step time.Duration, ns []int) ([]SeriesPoint, time.Duration, error) {
+	if !from.Before(to) {
+		return nil, 0, fmt.Errorf("from must be before to")
+	}
+	if step < 0 {
+		return nil, 0, fmt.Errorf("step must not be negative")
+	}
+	filter := make(map[int]bool, len(ns))
+	for _, n := range ns {
+		filter[n] = true
+	}
+
+	// Every shard has the same tiers, so pick one by its settings
+	tiers := ts.shards[0].tiers
+	tierIdx := len(tiers) - 1
+	for i, t := range tiers {
+		if !from.Before(time.Now().Add(-t.retention)) {
+			tierIdx = i
+			break
+		}
+	}
+	resolution := tiers[tierIdx].resolution
+	if step == 0 {
+		step = to.Sub(from) / defaultSeriesPoints
+	}
+	step = max((step+resolution-1)/resolution*resolution, resolution)
+	if to.Sub(from)/step > maxSeriesPoints {
+		return nil, 0, fmt.Errorf("query spans more than %d points, increase step", maxSeriesPoints)
+	}
+
+	start := from.Truncate(step)
+	points := make([]SeriesPoint, 0, to.Sub(start)/step+1)
+	for t := start; t.Before(to); t = t.Add(step) {
+		points = append(points, SeriesPoint{Start: t, Entries: make(map[int]*Entry)})
+	}
+	for i := range ts.shards {
+		sh := &ts.shards[i]
+		sh.mu.Lock()
+		for idx, bucket := range sh.tiers[tierIdx].buckets {
+			at := time.Unix(0, idx*int64(resolution))
+			if at.Before(start) || !at.Before(to) {
+				continue
+			}
+			p := points[at.Sub(start)/step]
+			for n, e := range bucket {
+				if len(filter) > 0 && !filter[n] {
+					continue
+				}
+				if agg, ok := p.Entries[n]; ok {
+					agg.Merge(e)
+				} else {
+					p.Entries[n] = e.Clone()
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return points, step, nil
+}
+
+// QueryStats returns bucketed time series of counts and latency percentiles.
+func (s *statsService) QueryStats(_ context.Context, r *pb.QueryStatsRequest) (*pb.QueryStatsResponse, error) {
+	if err := s.checkUnreplicated("time series"); err != nil {
+		return nil, err
+	}
+	to := time.Now()
+	if r.GetToMs() != 0 {
+		to = time.UnixMilli(r.GetToMs())
+	}
+	from := to.Add(-time.Hour)
+	if r.GetFromMs() != 0 {
+		from = time.UnixMilli(r.GetFromMs())
+	}
+	ns := make([]int, len(r.GetN()))
+	for i, n := range r.GetN() {
+		ns[i] = int(n)
+	}
+
+	points, step, err := s.series.Query(from, to, time.Duration(r.GetStepMs())*time.Millisecond, ns)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var series []*pb.TimeSeries
+	if len(ns) == 0 {
+		agg := &pb.TimeSeries{Aggregate: true}
+		for _, p := range points {
+			var total Entry
+			for _, e := range p.Entries {
+				total.Merge(e)
+			}
+			agg.Points = append(agg.Points, seriesPoint(p.Start, &total, step))
+		}
+		series = append(series, agg)
+	} else {
+		for _, n := range ns {
+			ser := &pb.TimeSeries{N: int32(n)}
+			for _, p := range points {
+				e, ok := p.Entries[n]
+				if !ok {
+					e = &Entry{}
+				}
+				ser.Points = append(ser.Points, seriesPoint(p.Start, e, step))
+			}
+			series = append(series, ser)
+		}
+	}
+
+	log.Printf("Returning time series: %d series, %d points, step=%v", len(series), len(points), step)
+	return &pb.QueryStatsResponse{StepMs: step.Milliseconds(), Series: series}, nil
+}
+
+// seriesPoint converts one bucket's aggregate into its wire form.
+func seriesPoint(start time.Time, e *Entry, step time.Duration) *pb.SeriesPoint {
+	p := &pb.SeriesPoint{
+		TimestampMs: start.UnixMilli(),
+		Count:       e.Count,
+		Qps:         rate(e.Count, step),
+	}
+	if e.Count == 0 {
+		return p
+	}
+	q := e.Latency.Quantiles(0.5, 0.9, 0.99)
+	p.AverageUs = micros(e.TotalTime) / float64(e.Count)
+	p.P50Us = micros(min(q[0], e.Max))
+	p.P90Us = micros(min(q[1], e.Max))
+	p.P99Us = micros(min(q[2], e.Max))
+	p.MaxUs = micros(e.Max)
+	return p
+}
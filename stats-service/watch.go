@@ -0,0 +1,171 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "fibonacci-grpc/proto/stats"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// watchBuffer is how many events a watcher may lag behind before events are dropped.
+	watchBuffer = 256
+
+	// minWatchInterval bounds how often a watcher can ask for snapshots.
+	minWatchInterval = 100 * time.Millisecond
+)
+
+// watcher is one WatchStats subscriber.
+type watcher struct {
+	events  chan Event
+	filter  map[int]bool // empty means every 'n'
+	dropped atomic.Int64
+}
+
+// wants reports whether the watcher is interested in n.
+func (w *watcher) wants(n int) bool {
+	return len(w.filter) == 0 || w.filter[n]
+}
+
+// watchHub fans recorded events out to watchers. Publishing never blocks:
+// a watcher whose buffer is full misses the event and is told how many it
+// missed, so one slow dashboard can't hold up RecordNo.
+type watchHub struct {
+	mu       sync.RWMutex
+	watchers map[*watcher]struct{}
+}
+
+// newWatchHub returns a hub with no watchers.
+func newWatchHub() *watchHub {
+	return &watchHub{watchers: make(map[*watcher]struct{})}
+}
+
+// subscribe registers a watcher for the given numbers (all if ns is empty).
+func (h *watchHub) subscribe(ns []int32) *watcher {
+	w := &watcher{events: make(chan Event, watchBuffer), filter: make(map[int]bool, len(ns))}
+	for _, n := range ns {
+		w.filter[int(n)] = true
+	}
+	h.mu.Lock()
+	h.watchers[w] = struct{}{}
+	h.mu.Unlock()
+	return w
+}
+
+// unsubscribe removes w from the hub.
+func (h *watchHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	delete(h.watchers, w)
+	h.mu.Unlock()
+}
+
+// publish delivers ev to every interested watcher without blocking.
+func (h *watchHub) publish(ev Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for w := range h.watchers {
+		if !w.wants(ev.N) {
+			continue
+		}
+		select {
+		case w.events <- ev:
+		default:
+			w.dropped.Add(1)
+		}
+	}
+}
+
+// WatchStats streams statistics to the caller until it disconnects.
+func (s *statsService) WatchStats(r *pb.WatchStatsRequest, stream grpc.ServerStreamingServer[pb.WatchStatsResponse]) error {
+	ctx := stream.Context()
+	snapshot := func() (*pb.WatchStatsResponse, error) {
+		resp, err := s.GetStats(ctx, &pb.StatsRequest{Window: r.GetWindow()})
+		if err != nil {
+			return nil, err
+		}
+		resp.FibonacciStats = filterStats(resp.GetFibonacciStats(), r.GetN())
+		return &pb.WatchStatsResponse{Update: &pb.WatchStatsResponse_Snapshot{Snapshot: resp}}, nil
+	}
+
+	if r.GetIntervalMs() == 0 || r.GetWindow() != "" {
+		// Events and windows only cover this replica's requests
+		if err := s.checkUnreplicated("watching events or a window"); err != nil {
+			return err
+		}
+	}
+
+	if r.GetIntervalMs() > 0 {
+		interval := max(time.Duration(r.GetIntervalMs())*time.Millisecond, minWatchInterval)
+		log.Printf("Watcher connected: snapshots every %v", interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			msg, err := snapshot()
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+
+	// Subscribe before the initial snapshot so no event falls in between.
+	w := s.hub.subscribe(r.GetN())
+	defer s.hub.unsubscribe(w)
+	log.Printf("Watcher connected: streaming events")
+
+	msg, err := snapshot()
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(msg); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-w.events:
+			err := stream.Send(&pb.WatchStatsResponse{
+				Update: &pb.WatchStatsResponse_Event{Event: &pb.RecordedEvent{
+					N:           int32(ev.N),
+					DurationUs:  micros(ev.Duration),
+					TimestampMs: ev.Time.UnixMilli(),
+					Weight:      ev.weight(),
+				}},
+				Dropped: w.dropped.Swap(0),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// filterStats keeps only the stats for the listed numbers (all if ns is empty).
+func filterStats(stats []*pb.FibonacciStat, ns []int32) []*pb.FibonacciStat {
+	if len(ns) == 0 {
+		return stats
+	}
+	keep := make(map[int32]bool, len(ns))
+	for _, n := range ns {
+		keep[n] = true
+	}
+	out := stats[:0]
+	for _, st := range stats {
+		if keep[st.GetN()] {
+			out = append(out, st)
+		}
+	}
+	return out
+}
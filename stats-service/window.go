@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// windowSlots is the number of ring buckets per window; each bucket covers span/windowSlots.
+const windowSlots = 60
+
+// windowSpans lists the supported rolling windows by name.
+var windowSpans = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+// windowSlot aggregates the events of one bucket-width interval.
+type windowSlot struct {
+	epoch   int64 // interval index (unix time / width) this slot currently holds
+	entries map[Key]*Entry
+}
+
+// ring is a fixed-size ring of slots covering one rolling window.
+// Slots are reused in place as time advances, so memory stays bounded.
+type ring struct {
+	width time.Duration
+	slots [windowSlots]windowSlot
+}
+
+// record adds ev to the slot for now, recycling it if it holds an older interval.
+func (r *ring) record(ev Event, now time.Time) {
+	epoch := now.UnixNano() / int64(r.width)
+	slot := &r.slots[epoch%windowSlots]
+	if slot.epoch != epoch || slot.entries == nil {
+		slot.epoch = epoch
+		slot.entries = make(map[Key]*Entry)
+	}
+	e, ok := slot.entries[ev.Key]
+	if !ok {
+		e = &Entry{}
+		slot.entries[ev.Key] = e
+	}
+	e.Add(ev)
+}
+
+// collect merges every slot still inside the window ending at now.
+func (r *ring) collect(now time.Time) map[Key]*Entry {
+	current := now.UnixNano() / int64(r.width)
+	out := make(map[Key]*Entry)
+	for i := range r.slots {
+		slot := &r.slots[i]
+		if slot.entries == nil || slot.epoch <= current-windowSlots || slot.epoch > current {
+			continue
+		}
+		for k, e := range slot.entries {
+			if agg, ok := out[k]; ok {
+				agg.Merge(e)
+			} else {
+				out[k] = e.Clone()
+			}
+		}
+	}
+	return out
+}
+
+// RollingWindows keeps recent statistics for each window in windowSpans.
+// Windows are process-local; they describe what this instance saw recently.
+// Keys are split across independently locked shards, each with its own rings.
+type RollingWindows struct {
+	started time.Time
+	shards  [recordShards]windowShard
+}
+
+// windowShard holds one ring per window for the keys assigned to it.
+type windowShard struct {
+	mu    sync.Mutex
+	rings map[string]*ring
+}
+
+// NewRollingWindows returns empty rings for every supported window.
+func NewRollingWindows() *RollingWindows {
+	w := &RollingWindows{started: time.Now()}
+	for i := range w.shards {
+		w.shards[i].rings = make(map[string]*ring, len(windowSpans))
+		for name, span := range windowSpans {
+			w.shards[i].rings[name] = &ring{width: span / windowSlots}
+		}
+	}
+	return w
+}
+
+// Record adds ev to every window.
+func (w *RollingWindows) Record(ev Event) {
+	now := time.Now()
+	sh := &w.shards[shardOf(ev.Key)]
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	for _, r := range sh.rings {
+		r.record(ev, now)
+	}
+}
+
+// Entries returns the aggregates for the named window and the time span they
+// cover, which is shorter than the window while the process is younger than it.
+func (w *RollingWindows) Entries(name string) (map[Key]*Entry, time.Duration, error) {
+	span, ok := windowSpans[name]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown window %q (want 1m, 5m, 1h or 24h)", name)
+	}
+	now := time.Now()
+	out := make(map[Key]*Entry)
+	for i := range w.shards {
+		sh := &w.shards[i]
+		sh.mu.Lock()
+		// Shards hold disjoint keys, so their aggregates never need merging
+		for k, e := range sh.rings[name].collect(now) {
+			out[k] = e
+		}
+		sh.mu.Unlock()
+	}
+	return out, min(span, now.Sub(w.started)), nil
+}
+
+// Forget drops the aggregates of every key matching match from all windows.
+func (w *RollingWindows) Forget(match func(Key) bool) {
+	for i := range w.shards {
+		sh := &w.shards[i]
+		sh.mu.Lock()
+		for _, r := range sh.rings {
+			for j := range r.slots {
+				for k := range r.slots[j].entries {
+					if match(k) {
+						delete(r.slots[j].entries, k)
+					}
+				}
+			}
+		}
+		sh.mu.Unlock()
+	}
+}